@@ -19,8 +19,16 @@ type ResourceTrackerSpec struct {
 }
 
 // ResourceTarget defines the target resource to monitor
+// +kubebuilder:validation:XValidation:rule="has(self.__namespace__) || has(self.namespaces) || has(self.namespaceSelector) || (has(self.allNamespaces) && self.allNamespaces)",message="one of namespace, namespaces, namespaceSelector or allNamespaces is required"
 type ResourceTarget struct {
-	// +kubebuilder:validation:Enum=Deployment;StatefulSet;Pod
+	// APIVersion of the resource, e.g. cert-manager.io/v1. Required for kinds other
+	// than Deployment, StatefulSet, DaemonSet, Job, CronJob, Pod and Argo Rollouts'
+	// Rollout, which are then tracked generically with kstatus readiness rules.
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the resource
+	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:Required
 	Kind string `json:"kind"`
 
@@ -28,9 +36,30 @@ type ResourceTarget struct {
 	// Name is optional; if empty, all resources of the specified Kind in the Namespace will be monitored
 	Name string `json:"name,omitempty"`
 
-	// +kubebuilder:validation:Required
+	// +optional
+	// Selector restricts the resources monitored when Name is empty to those whose
+	// labels match, e.g. every Deployment of a team
+	// +kubebuilder:validation:XValidation:rule="!has(self.matchExpressions) || self.matchExpressions.all(e, e.operator in ['In', 'NotIn'] ? (has(e.values) && size(e.values) > 0) : (e.operator in ['Exists', 'DoesNotExist'] && (!has(e.values) || size(e.values) == 0)))",message="selector operators must be In or NotIn with values, or Exists or DoesNotExist without values"
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// +optional
 	// Namespace to monitor resources in
-	Namespace string `json:"namespace"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// +optional
+	// Namespaces lists further namespaces to monitor, e.g. one per environment
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// +optional
+	// NamespaceSelector adds the namespaces whose labels match. Trackers follow
+	// namespaces as they are created or relabeled.
+	// +kubebuilder:validation:XValidation:rule="!has(self.matchExpressions) || self.matchExpressions.all(e, e.operator in ['In', 'NotIn'] ? (has(e.values) && size(e.values) > 0) : (e.operator in ['Exists', 'DoesNotExist'] && (!has(e.values) || size(e.values) == 0)))",message="namespaceSelector operators must be In or NotIn with values, or Exists or DoesNotExist without values"
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// +optional
+	// AllNamespaces monitors resources in every namespace, ignoring the other
+	// namespace fields
+	AllNamespaces bool `json:"allNamespaces,omitempty"`
 }
 
 // +kubebuilder:printcolumn:name="Kind",type="string",JSONPath=".spec.target.kind"
@@ -41,10 +70,459 @@ type ResourceTarget struct {
 
 // NotifyConfig defines notification configuration
 type NotifyConfig struct {
-	Slack       string `json:"slack,omitempty"`
-	Email       string `json:"email,omitempty"`
-	RetryCount  int    `json:"retryCount,omitempty"`
-	AlertOnFail bool   `json:"alertOnFail,omitempty"`
+	// Slack incoming webhook URL. Prefer SlackSecretRef so the URL is not
+	// readable by everyone who can read the ResourceTracker.
+	Slack string `json:"slack,omitempty"`
+
+	// SlackSecretRef points to a Secret key holding the Slack webhook URL.
+	// It takes precedence over Slack.
+	// +optional
+	SlackSecretRef *SecretKeyRef `json:"slackSecretRef,omitempty"`
+
+	// SlackStyle selects the Slack message format: "text" posts the plain message,
+	// "blocks" posts a Block Kit attachment with a status colour bar and detail fields.
+	// The plain message is always included as the notification fallback.
+	// +kubebuilder:validation:Enum=text;blocks
+	// +kubebuilder:default=text
+	// +optional
+	SlackStyle string `json:"slackStyle,omitempty"`
+
+	// DashboardURL is a Go text/template rendered over the notification event,
+	// e.g. "https://grafana.example.com/d/app?var-namespace={{ .Namespace }}".
+	// Rich message styles add a button linking to it.
+	// +optional
+	DashboardURL string `json:"dashboardURL,omitempty"`
+
+	// SlackBot posts through the Slack Web API with a bot token instead of an
+	// incoming webhook, grouping the updates of each rollout into one thread
+	// +optional
+	SlackBot *SlackBotConfig `json:"slackBot,omitempty"`
+
+	// Teams incoming webhook (or Workflows) URL that receives Adaptive Card messages
+	// +optional
+	Teams string `json:"teams,omitempty"`
+
+	// TeamsSecretRef points to a Secret key holding the Teams webhook URL.
+	// It takes precedence over Teams.
+	// +optional
+	TeamsSecretRef *SecretKeyRef `json:"teamsSecretRef,omitempty"`
+
+	// PagerDuty triggers an incident through the Events API v2 when a tracked
+	// resource fails (requires AlertOnFail) and resolves it once the resource is ready again
+	// +optional
+	PagerDuty *PagerDutyConfig `json:"pagerDuty,omitempty"`
+
+	// Opsgenie creates an alert when a tracked resource fails (requires AlertOnFail)
+	// and closes it once the resource is ready again
+	// +optional
+	Opsgenie *OpsgenieConfig `json:"opsgenie,omitempty"`
+
+	// Discord webhook that receives notifications as embeds
+	// +optional
+	Discord *ChatWebhookConfig `json:"discord,omitempty"`
+
+	// Telegram chat notifications are sent to through a bot
+	// +optional
+	Telegram *TelegramConfig `json:"telegram,omitempty"`
+
+	// Mattermost incoming webhook
+	// +optional
+	Mattermost *MattermostConfig `json:"mattermost,omitempty"`
+
+	// GoogleChat space webhook
+	// +optional
+	GoogleChat *ChatWebhookConfig `json:"googleChat,omitempty"`
+
+	// Email is a comma separated list of recipient addresses
+	Email string `json:"email,omitempty"`
+
+	// SMTP server used to deliver email notifications
+	// +optional
+	SMTP *SMTPConfig `json:"smtp,omitempty"`
+
+	// Webhook sends every notification event to a generic HTTP endpoint
+	// +optional
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+
+	// CloudEvents emits every state transition, including not-ready events,
+	// as a CloudEvents 1.0 event to an HTTP sink
+	// +optional
+	CloudEvents *CloudEventsConfig `json:"cloudEvents,omitempty"`
+
+	// RetryCount is the number of times a failed notification is retried
+	// with exponential backoff before it is dropped
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+
+	// Routes select the channels of each event by its type, severity, resource
+	// labels, annotations and images. Routes are evaluated in order and the first
+	// matching route wins unless it sets Continue; events that match no route are
+	// not sent. Without routes every event goes to all configured channels.
+	// +optional
+	Routes []NotificationRoute `json:"routes,omitempty"`
+
+	// Digest batches the notifications of a namespace-wide tracker (empty target
+	// name) into one message per window. Incident channels (PagerDuty, Opsgenie)
+	// still receive every event.
+	// +optional
+	Digest *DigestConfig `json:"digest,omitempty"`
+
+	// Schedule defines quiet hours during which non-critical notifications are
+	// suppressed or held back for a digest sent when the quiet hours end
+	// +optional
+	Schedule *NotifySchedule `json:"schedule,omitempty"`
+
+	// Templates overrides the notification text of each event type
+	// +optional
+	Templates *NotificationTemplates `json:"templates,omitempty"`
+
+	// AlertOnFail sends a failure alert when a tracked resource fails, e.g. a
+	// Deployment exceeding its progress deadline or a Pod in CrashLoopBackOff
+	// +optional
+	AlertOnFail bool `json:"alertOnFail,omitempty"`
+}
+
+// NotificationRoute sends the events matching Match to Channels
+type NotificationRoute struct {
+	// Name identifies the route in logs
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Match selects the events of this route; an empty match selects every event
+	// +optional
+	Match RouteMatch `json:"match,omitempty"`
+
+	// Channels the matching events are sent to, e.g. slack, pagerduty or email.
+	// The channels must also be configured on the tracker.
+	// +kubebuilder:validation:MinItems=1
+	Channels []string `json:"channels"`
+
+	// Continue evaluates the following routes after this one matched
+	// +optional
+	Continue bool `json:"continue,omitempty"`
+}
+
+// RouteMatch selects notification events. All given matchers must match;
+// a list matches when any of its entries does.
+type RouteMatch struct {
+	// Events are the event types to match
+	// +kubebuilder:validation:items:Enum=ready;not-ready;completed;failed;recovered;image-changed;scaled;deleted;rollout-step;rollout-paused;analysis;rollout-aborted;rollout-promoted
+	// +optional
+	Events []string `json:"events,omitempty"`
+
+	// Severities to match; failed events are critical, not-ready and deleted
+	// events warning and all other events info
+	// +kubebuilder:validation:items:Enum=critical;warning;info
+	// +optional
+	Severities []string `json:"severities,omitempty"`
+
+	// Labels selects resources by their labels
+	// +optional
+	Labels *metav1.LabelSelector `json:"labels,omitempty"`
+
+	// Annotations the resource must have with exactly these values
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Images are glob patterns matched against the container images,
+	// e.g. "registry.example.com/payments/*"
+	// +optional
+	Images []string `json:"images,omitempty"`
+}
+
+// NotificationTemplates are Go text/templates rendered over the notification event
+// (see NotificationEvent in the README for the available fields and helpers).
+// Event types without a template use the built-in message.
+type NotificationTemplates struct {
+	// Ready is used when a resource becomes ready
+	// +optional
+	Ready string `json:"ready,omitempty"`
+
+	// NotReady is used when a ready resource stops being ready. Only event sinks
+	// such as CloudEvents receive these events.
+	// +optional
+	NotReady string `json:"notReady,omitempty"`
+
+	// Completed is used when a Job completes successfully
+	// +optional
+	Completed string `json:"completed,omitempty"`
+
+	// Failed is used when a resource fails (requires AlertOnFail)
+	// +optional
+	Failed string `json:"failed,omitempty"`
+
+	// ImageChanged is used when the container images of a resource change
+	// +optional
+	ImageChanged string `json:"imageChanged,omitempty"`
+
+	// Recovered is sent to incident channels and event sinks when a failure clears
+	// +optional
+	Recovered string `json:"recovered,omitempty"`
+
+	// Scaled is used when the desired replicas of a Deployment or StatefulSet change
+	// +optional
+	Scaled string `json:"scaled,omitempty"`
+
+	// Deleted is used when a tracked resource is deleted
+	// +optional
+	Deleted string `json:"deleted,omitempty"`
+
+	// RolloutStep is used when an Argo Rollout moves to another canary step
+	// +optional
+	RolloutStep string `json:"rolloutStep,omitempty"`
+
+	// RolloutPaused is used when an Argo Rollout pauses
+	// +optional
+	RolloutPaused string `json:"rolloutPaused,omitempty"`
+
+	// Analysis is used when an analysis run of an Argo Rollout finishes
+	// +optional
+	Analysis string `json:"analysis,omitempty"`
+
+	// RolloutAborted is used when an Argo Rollout is aborted
+	// +optional
+	RolloutAborted string `json:"rolloutAborted,omitempty"`
+
+	// RolloutPromoted is used when a new revision of an Argo Rollout becomes stable
+	// +optional
+	RolloutPromoted string `json:"rolloutPromoted,omitempty"`
+
+	// Digest is used for the batched notifications of a digest window
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// NotifySchedule defines quiet hours for notifications. Critical notifications
+// (failures) and incident channels (PagerDuty, Opsgenie) are never held back.
+type NotifySchedule struct {
+	// TimeZone the quiet hours are defined in, as an IANA name such as "Asia/Seoul"
+	// +kubebuilder:default=UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// QuietHours are the windows during which non-critical notifications are held back
+	// +kubebuilder:validation:MinItems=1
+	QuietHours []QuietWindow `json:"quietHours"`
+
+	// Action taken on non-critical notifications during quiet hours: "suppress"
+	// drops them, "digest" sends them as one digest when the quiet hours end
+	// +kubebuilder:validation:Enum=suppress;digest
+	// +kubebuilder:default=digest
+	// +optional
+	Action string `json:"action,omitempty"`
+}
+
+// QuietWindow is a time range of day, or a cron schedule starting a window of the given duration
+type QuietWindow struct {
+	// Start and End are times of day ("22:00", "07:00"); a window ending before
+	// it starts runs past midnight
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +optional
+	Start string `json:"start,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +optional
+	End string `json:"end,omitempty"`
+
+	// Days restrict a time range to the days it starts on, e.g. ["Sat", "Sun"]
+	// +kubebuilder:validation:items:Enum=Sun;Mon;Tue;Wed;Thu;Fri;Sat
+	// +optional
+	Days []string `json:"days,omitempty"`
+
+	// Cron is a five field cron expression ("0 2 * * *") opening a window of Duration
+	// +optional
+	Cron string `json:"cron,omitempty"`
+
+	// Duration of the windows opened by Cron
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// DigestConfig defines how notifications are batched into digests
+type DigestConfig struct {
+	// Window is how long transitions are collected before the digest is sent, e.g. "30s"
+	// +kubebuilder:validation:Required
+	Window metav1.Duration `json:"window"`
+}
+
+// SecretKeyRef selects a key of a Secret in the ResourceTracker's namespace
+type SecretKeyRef struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// SlackBotConfig defines a Slack bot token and the channel it posts to
+type SlackBotConfig struct {
+	// TokenSecretRef points to a Secret key holding the bot token (xoxb-...).
+	// The bot needs the chat:write scope and must be a member of the channel.
+	// +kubebuilder:validation:Required
+	TokenSecretRef SecretKeyRef `json:"tokenSecretRef"`
+
+	// Channel name or ID to post to
+	// +kubebuilder:validation:Required
+	Channel string `json:"channel"`
+}
+
+// PagerDutyConfig defines the PagerDuty service incidents are sent to
+type PagerDutyConfig struct {
+	// RoutingKeySecretRef points to a Secret key holding the Events API v2
+	// integration (routing) key of the service
+	// +kubebuilder:validation:Required
+	RoutingKeySecretRef SecretKeyRef `json:"routingKeySecretRef"`
+
+	// Severity of the triggered incidents
+	// +kubebuilder:validation:Enum=critical;error;warning;info
+	// +kubebuilder:default=error
+	// +optional
+	Severity string `json:"severity,omitempty"`
+}
+
+// OpsgenieConfig defines the Opsgenie API integration alerts are created with
+type OpsgenieConfig struct {
+	// APIKeySecretRef points to a Secret key holding the API integration key
+	// +kubebuilder:validation:Required
+	APIKeySecretRef SecretKeyRef `json:"apiKeySecretRef"`
+
+	// APIURL of the Opsgenie instance; use https://api.eu.opsgenie.com for the EU region
+	// +kubebuilder:default="https://api.opsgenie.com"
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// Priority of the created alerts
+	// +kubebuilder:validation:Enum=P1;P2;P3;P4;P5
+	// +kubebuilder:default=P3
+	// +optional
+	Priority string `json:"priority,omitempty"`
+}
+
+// ChatWebhookConfig is the incoming webhook of a chat service
+type ChatWebhookConfig struct {
+	// URLSecretRef points to a Secret key holding the webhook URL
+	// +kubebuilder:validation:Required
+	URLSecretRef SecretKeyRef `json:"urlSecretRef"`
+}
+
+// TelegramConfig defines the bot and chat of Telegram notifications
+type TelegramConfig struct {
+	// BotTokenSecretRef points to a Secret key holding the bot token
+	// +kubebuilder:validation:Required
+	BotTokenSecretRef SecretKeyRef `json:"botTokenSecretRef"`
+
+	// ChatID of the user, group or channel (e.g. "-1001234567890" or "@deploys")
+	// +kubebuilder:validation:Required
+	ChatID string `json:"chatID"`
+
+	// APIURL of the Bot API server, for self-hosted servers
+	// +kubebuilder:default="https://api.telegram.org"
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+}
+
+// MattermostConfig defines a Mattermost incoming webhook
+type MattermostConfig struct {
+	// URLSecretRef points to a Secret key holding the webhook URL
+	// +kubebuilder:validation:Required
+	URLSecretRef SecretKeyRef `json:"urlSecretRef"`
+
+	// Channel overrides the webhook's default channel, if the webhook allows it
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// Username overrides the webhook's display name, if the webhook allows it
+	// +optional
+	Username string `json:"username,omitempty"`
+}
+
+// WebhookConfig defines a generic outbound HTTP webhook
+type WebhookConfig struct {
+	// URL of the webhook endpoint
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// URLSecretRef points to a Secret key holding the URL. It takes precedence over URL.
+	// +optional
+	URLSecretRef *SecretKeyRef `json:"urlSecretRef,omitempty"`
+
+	// +kubebuilder:validation:Enum=POST;PUT;PATCH
+	// +kubebuilder:default=POST
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Headers added to the request. Content-Type defaults to application/json.
+	// +optional
+	Headers []WebhookHeader `json:"headers,omitempty"`
+
+	// Body is a Go text/template rendered over the notification event
+	// (type, kind, namespace, name, readyReplicas, totalReplicas, images,
+	// previousImages, phase, reason, duration, message, timestamp).
+	// The event is sent as JSON when empty.
+	// +optional
+	Body string `json:"body,omitempty"`
+}
+
+// CloudEventsConfig defines the sink of CloudEvents notifications
+type CloudEventsConfig struct {
+	// SinkURL of the receiver, e.g. a Knative broker
+	// +optional
+	SinkURL string `json:"sinkURL,omitempty"`
+
+	// SinkSecretRef points to a Secret key holding the sink URL. It takes precedence over SinkURL.
+	// +optional
+	SinkSecretRef *SecretKeyRef `json:"sinkSecretRef,omitempty"`
+
+	// Mode is the HTTP content mode: structured sends the whole event as
+	// application/cloudevents+json, binary sends the attributes as ce- headers
+	// +kubebuilder:validation:Enum=structured;binary
+	// +kubebuilder:default=structured
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Source overrides the event source, which defaults to the tracker's API path
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// WebhookHeader is a single HTTP header of a webhook request
+type WebhookHeader struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom reads the header value from a Secret key
+	// +optional
+	ValueFrom *SecretKeyRef `json:"valueFrom,omitempty"`
+}
+
+// SMTPConfig defines the SMTP server used for email notifications
+type SMTPConfig struct {
+	// +kubebuilder:validation:Required
+	Host string `json:"host"`
+
+	// +kubebuilder:default=587
+	// +optional
+	Port int `json:"port,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// From address of the notification emails
+	From string `json:"from"`
+
+	// TLS mode: "starttls" upgrades a plain connection, "tls" connects over implicit TLS
+	// (usually port 465) and "none" disables encryption
+	// +kubebuilder:validation:Enum=starttls;tls;none
+	// +kubebuilder:default=starttls
+	// +optional
+	TLS string `json:"tls,omitempty"`
+
+	// CredentialsSecret is the name of a Secret in the tracker namespace
+	// holding "username" and "password" keys for SMTP AUTH
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
 }
 
 // ResourceState tracks the current state of the resource
@@ -97,6 +575,66 @@ type ResourceTrackerStatus struct {
 	// 리소스 상태 추적을 위한 필드 추가
 	ResourceStatus   map[string]bool   `json:"resourceStatus,omitempty"`
 	GenerationStatus map[string]string `json:"generationStatus,omitempty"`
+
+	// Last time each resource changed readiness
+	TransitionTimes map[string]metav1.Time `json:"transitionTimes,omitempty"`
+
+	// Failure reason of each resource currently considered failed
+	Failures map[string]string `json:"failures,omitempty"`
+
+	// Last time each resource started or stopped failing
+	FailureTimes map[string]metav1.Time `json:"failureTimes,omitempty"`
+
+	// Desired replicas of each resource when it was last observed
+	ObservedReplicas map[string]int32 `json:"observedReplicas,omitempty"`
+
+	// Slack thread of the current rollout of each resource, used by slackBot
+	SlackThreads map[string]SlackThread `json:"slackThreads,omitempty"`
+
+	// Number of consecutive failed Jobs of each CronJob
+	ConsecutiveFailures map[string]int32 `json:"consecutiveFailures,omitempty"`
+
+	// Progress of each Argo Rollout when it was last observed
+	Rollouts map[string]RolloutState `json:"rollouts,omitempty"`
+
+	// Dedup key of the open PagerDuty incident of each failed resource
+	PagerDutyIncidents map[string]string `json:"pagerDutyIncidents,omitempty"`
+}
+
+// RolloutState is the progress of an Argo Rollout, compared with the next
+// observation to notify steps, pauses, analysis results, aborts and promotions
+type RolloutState struct {
+	// Step is the index of the current canary step
+	// +optional
+	Step *int32 `json:"step,omitempty"`
+
+	// PauseReasons of the rollout's pause conditions
+	// +optional
+	PauseReasons []string `json:"pauseReasons,omitempty"`
+
+	// AnalysisRuns maps the current analysis runs to their status
+	// +optional
+	AnalysisRuns map[string]string `json:"analysisRuns,omitempty"`
+
+	// Aborted is true while the update is aborted
+	// +optional
+	Aborted bool `json:"aborted,omitempty"`
+
+	// StableRS is the pod template hash of the stable ReplicaSet
+	// +optional
+	StableRS string `json:"stableRS,omitempty"`
+}
+
+// SlackThread identifies the Slack thread a rollout's updates are posted to
+type SlackThread struct {
+	// Revision of the rollout, e.g. the Deployment revision or StatefulSet update revision
+	Revision string `json:"revision"`
+
+	// Channel the thread was started in
+	Channel string `json:"channel"`
+
+	// TS is the timestamp of the parent message
+	TS string `json:"ts"`
 }
 
 // +kubebuilder:object:root=true
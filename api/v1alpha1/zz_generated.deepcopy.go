@@ -11,9 +11,62 @@ Licensed under the MIT License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChatWebhookConfig) DeepCopyInto(out *ChatWebhookConfig) {
+	*out = *in
+	out.URLSecretRef = in.URLSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChatWebhookConfig.
+func (in *ChatWebhookConfig) DeepCopy() *ChatWebhookConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ChatWebhookConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsConfig) DeepCopyInto(out *CloudEventsConfig) {
+	*out = *in
+	if in.SinkSecretRef != nil {
+		in, out := &in.SinkSecretRef, &out.SinkSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsConfig.
+func (in *CloudEventsConfig) DeepCopy() *CloudEventsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DigestConfig) DeepCopyInto(out *DigestConfig) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigestConfig.
+func (in *DigestConfig) DeepCopy() *DigestConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DigestConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageState) DeepCopyInto(out *ImageState) {
 	*out = *in
@@ -29,9 +82,143 @@ func (in *ImageState) DeepCopy() *ImageState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MattermostConfig) DeepCopyInto(out *MattermostConfig) {
+	*out = *in
+	out.URLSecretRef = in.URLSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MattermostConfig.
+func (in *MattermostConfig) DeepCopy() *MattermostConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MattermostConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationRoute) DeepCopyInto(out *NotificationRoute) {
+	*out = *in
+	in.Match.DeepCopyInto(&out.Match)
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationRoute.
+func (in *NotificationRoute) DeepCopy() *NotificationRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationTemplates) DeepCopyInto(out *NotificationTemplates) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTemplates.
+func (in *NotificationTemplates) DeepCopy() *NotificationTemplates {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationTemplates)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotifyConfig) DeepCopyInto(out *NotifyConfig) {
 	*out = *in
+	if in.SlackSecretRef != nil {
+		in, out := &in.SlackSecretRef, &out.SlackSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.SlackBot != nil {
+		in, out := &in.SlackBot, &out.SlackBot
+		*out = new(SlackBotConfig)
+		**out = **in
+	}
+	if in.TeamsSecretRef != nil {
+		in, out := &in.TeamsSecretRef, &out.TeamsSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDutyConfig)
+		**out = **in
+	}
+	if in.Opsgenie != nil {
+		in, out := &in.Opsgenie, &out.Opsgenie
+		*out = new(OpsgenieConfig)
+		**out = **in
+	}
+	if in.Discord != nil {
+		in, out := &in.Discord, &out.Discord
+		*out = new(ChatWebhookConfig)
+		**out = **in
+	}
+	if in.Telegram != nil {
+		in, out := &in.Telegram, &out.Telegram
+		*out = new(TelegramConfig)
+		**out = **in
+	}
+	if in.Mattermost != nil {
+		in, out := &in.Mattermost, &out.Mattermost
+		*out = new(MattermostConfig)
+		**out = **in
+	}
+	if in.GoogleChat != nil {
+		in, out := &in.GoogleChat, &out.GoogleChat
+		*out = new(ChatWebhookConfig)
+		**out = **in
+	}
+	if in.SMTP != nil {
+		in, out := &in.SMTP, &out.SMTP
+		*out = new(SMTPConfig)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = new(CloudEventsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]NotificationRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Digest != nil {
+		in, out := &in.Digest, &out.Digest
+		*out = new(DigestConfig)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(NotifySchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = new(NotificationTemplates)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifyConfig.
@@ -44,6 +231,85 @@ func (in *NotifyConfig) DeepCopy() *NotifyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotifySchedule) DeepCopyInto(out *NotifySchedule) {
+	*out = *in
+	if in.QuietHours != nil {
+		in, out := &in.QuietHours, &out.QuietHours
+		*out = make([]QuietWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifySchedule.
+func (in *NotifySchedule) DeepCopy() *NotifySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(NotifySchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsgenieConfig) DeepCopyInto(out *OpsgenieConfig) {
+	*out = *in
+	out.APIKeySecretRef = in.APIKeySecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieConfig.
+func (in *OpsgenieConfig) DeepCopy() *OpsgenieConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsgenieConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDutyConfig) DeepCopyInto(out *PagerDutyConfig) {
+	*out = *in
+	out.RoutingKeySecretRef = in.RoutingKeySecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyConfig.
+func (in *PagerDutyConfig) DeepCopy() *PagerDutyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDutyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuietWindow) DeepCopyInto(out *QuietWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuietWindow.
+func (in *QuietWindow) DeepCopy() *QuietWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(QuietWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceState) DeepCopyInto(out *ResourceState) {
 	*out = *in
@@ -63,6 +329,21 @@ func (in *ResourceState) DeepCopy() *ResourceState {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceTarget) DeepCopyInto(out *ResourceTarget) {
 	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTarget.
@@ -80,7 +361,7 @@ func (in *ResourceTracker) DeepCopyInto(out *ResourceTracker) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -137,8 +418,8 @@ func (in *ResourceTrackerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceTrackerSpec) DeepCopyInto(out *ResourceTrackerSpec) {
 	*out = *in
-	out.Target = in.Target
-	out.Notify = in.Notify
+	in.Target.DeepCopyInto(&out.Target)
+	in.Notify.DeepCopyInto(&out.Notify)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTrackerSpec.
@@ -178,6 +459,62 @@ func (in *ResourceTrackerStatus) DeepCopyInto(out *ResourceTrackerStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.TransitionTimes != nil {
+		in, out := &in.TransitionTimes, &out.TransitionTimes
+		*out = make(map[string]v1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailureTimes != nil {
+		in, out := &in.FailureTimes, &out.FailureTimes
+		*out = make(map[string]v1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ObservedReplicas != nil {
+		in, out := &in.ObservedReplicas, &out.ObservedReplicas
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SlackThreads != nil {
+		in, out := &in.SlackThreads, &out.SlackThreads
+		*out = make(map[string]SlackThread, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConsecutiveFailures != nil {
+		in, out := &in.ConsecutiveFailures, &out.ConsecutiveFailures
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Rollouts != nil {
+		in, out := &in.Rollouts, &out.Rollouts
+		*out = make(map[string]RolloutState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.PagerDutyIncidents != nil {
+		in, out := &in.PagerDutyIncidents, &out.PagerDutyIncidents
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTrackerStatus.
@@ -189,3 +526,201 @@ func (in *ResourceTrackerStatus) DeepCopy() *ResourceTrackerStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutState) DeepCopyInto(out *RolloutState) {
+	*out = *in
+	if in.Step != nil {
+		in, out := &in.Step, &out.Step
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PauseReasons != nil {
+		in, out := &in.PauseReasons, &out.PauseReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnalysisRuns != nil {
+		in, out := &in.AnalysisRuns, &out.AnalysisRuns
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutState.
+func (in *RolloutState) DeepCopy() *RolloutState {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMatch) DeepCopyInto(out *RouteMatch) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMatch.
+func (in *RouteMatch) DeepCopy() *RouteMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SMTPConfig) DeepCopyInto(out *SMTPConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMTPConfig.
+func (in *SMTPConfig) DeepCopy() *SMTPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SMTPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackBotConfig) DeepCopyInto(out *SlackBotConfig) {
+	*out = *in
+	out.TokenSecretRef = in.TokenSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackBotConfig.
+func (in *SlackBotConfig) DeepCopy() *SlackBotConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackBotConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackThread) DeepCopyInto(out *SlackThread) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackThread.
+func (in *SlackThread) DeepCopy() *SlackThread {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackThread)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelegramConfig) DeepCopyInto(out *TelegramConfig) {
+	*out = *in
+	out.BotTokenSecretRef = in.BotTokenSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelegramConfig.
+func (in *TelegramConfig) DeepCopy() *TelegramConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TelegramConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookConfig) DeepCopyInto(out *WebhookConfig) {
+	*out = *in
+	if in.URLSecretRef != nil {
+		in, out := &in.URLSecretRef, &out.URLSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]WebhookHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookConfig.
+func (in *WebhookConfig) DeepCopy() *WebhookConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookHeader) DeepCopyInto(out *WebhookHeader) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookHeader.
+func (in *WebhookHeader) DeepCopy() *WebhookHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookHeader)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,110 @@
+// controllers/cloudevents.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// CloudEvents HTTP content modes
+const (
+	CloudEventsStructured = "structured"
+	CloudEventsBinary     = "binary"
+)
+
+// cloudEventTypePrefix prefixes the event type, e.g. k8s.ddukbg.resourcetracker.ready
+const cloudEventTypePrefix = "k8s.ddukbg.resourcetracker."
+
+// CloudEvent is a CloudEvents 1.0 event in the structured JSON format
+type CloudEvent struct {
+	SpecVersion     string            `json:"specversion"`
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	Type            string            `json:"type"`
+	Subject         string            `json:"subject,omitempty"`
+	Time            string            `json:"time,omitempty"`
+	DataContentType string            `json:"datacontenttype"`
+	Data            NotificationEvent `json:"data"`
+}
+
+// CloudEventsNotifier emits notification events as CloudEvents to an HTTP sink
+type CloudEventsNotifier struct {
+	// Client reads the Secret holding the sink URL
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *CloudEventsNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.CloudEvents != nil
+}
+
+// Notify implements Notifier
+func (n *CloudEventsNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	cfg := tracker.Spec.Notify.CloudEvents
+
+	sinkURL := cfg.SinkURL
+	if cfg.SinkSecretRef != nil {
+		url, err := secretValue(ctx, n.Client, tracker.Namespace, cfg.SinkSecretRef)
+		if err != nil {
+			return err
+		}
+		sinkURL = url
+	}
+	if sinkURL == "" {
+		return fmt.Errorf("notify.cloudEvents requires sinkURL or sinkSecretRef")
+	}
+
+	ce := cloudEventFor(tracker, event)
+	if cfg.Mode == CloudEventsBinary {
+		// 속성은 ce- 헤더로, 본문에는 데이터만 전송
+		headers := map[string]string{
+			"ce-specversion": ce.SpecVersion,
+			"ce-id":          ce.ID,
+			"ce-source":      ce.Source,
+			"ce-type":        ce.Type,
+		}
+		if ce.Subject != "" {
+			headers["ce-subject"] = ce.Subject
+		}
+		if ce.Time != "" {
+			headers["ce-time"] = ce.Time
+		}
+		return sendJSON(ctx, ChannelCloudEvents, http.MethodPost, sinkURL, headers, ce.Data)
+	}
+
+	headers := map[string]string{"Content-Type": "application/cloudevents+json; charset=utf-8"}
+	return sendJSON(ctx, ChannelCloudEvents, http.MethodPost, sinkURL, headers, ce)
+}
+
+// cloudEventFor wraps the notification event in a CloudEvent. The event ID is
+// stable across retries and restarts, so sinks can drop redelivered events.
+func cloudEventFor(tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) CloudEvent {
+	source := tracker.Spec.Notify.CloudEvents.Source
+	if source == "" {
+		source = fmt.Sprintf("/apis/%s/namespaces/%s/resourcetrackers/%s",
+			ddukbgv1alpha1.GroupVersion.String(), tracker.Namespace, tracker.Name)
+	}
+
+	ce := CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              event.ID,
+		Source:          source,
+		Type:            cloudEventTypePrefix + event.Type,
+		DataContentType: "application/json",
+		Data:            event,
+	}
+	if event.Name != "" {
+		ce.Subject = fmt.Sprintf("%s/%s/%s", event.Kind, event.Namespace, event.Name)
+	}
+	if !event.Timestamp.IsZero() {
+		ce.Time = event.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+	return ce
+}
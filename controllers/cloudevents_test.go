@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type receivedCloudEvent struct {
+	header http.Header
+	body   []byte
+}
+
+// newCloudEventsReceiver starts a local sink recording every request
+func newCloudEventsReceiver(t *testing.T) (*httptest.Server, chan receivedCloudEvent) {
+	requests := make(chan receivedCloudEvent, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- receivedCloudEvent{header: r.Header, body: body}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestCloudEventsStructured(t *testing.T) {
+	server, requests := newCloudEventsReceiver(t)
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				CloudEvents: &ddukbgv1alpha1.CloudEventsConfig{SinkURL: server.URL},
+			},
+		},
+	}
+	slack := newFakeNotifier()
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, slack)
+	registry.Register(ChannelCloudEvents, &CloudEventsNotifier{})
+	r := &ResourceTrackerReconciler{Notifiers: registry}
+
+	ctx := context.Background()
+	at := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	event := NotificationEvent{Type: EventNotReady, Kind: "Deployment", Namespace: "default", Name: "api",
+		ReadyReplicas: 1, TotalReplicas: 3, Timestamp: at}
+	require.NoError(t, r.sendNotifications(ctx, tracker, event))
+
+	req := <-requests
+	assert.Equal(t, "application/cloudevents+json; charset=utf-8", req.header.Get("Content-Type"))
+	var ce CloudEvent
+	require.NoError(t, json.Unmarshal(req.body, &ce))
+	assert.Equal(t, "1.0", ce.SpecVersion)
+	assert.Equal(t, "k8s.ddukbg.resourcetracker.not-ready", ce.Type)
+	assert.Equal(t, "/apis/ddukbg.k8s/v1alpha1/namespaces/default/resourcetrackers/test-tracker", ce.Source)
+	assert.Equal(t, "Deployment/default/api", ce.Subject)
+	assert.Equal(t, "2026-10-16T09:30:00Z", ce.Time)
+	assert.Equal(t, "application/json", ce.DataContentType)
+	assert.NotEmpty(t, ce.ID)
+	assert.Equal(t, int32(1), ce.Data.ReadyReplicas)
+	assert.Equal(t, SeverityWarning, ce.Data.Severity)
+	assert.Empty(t, slack.events, "not-ready events only go to event sinks")
+
+	event.Type = EventReady
+	event.ReadyReplicas = 3
+	require.NoError(t, r.sendNotifications(ctx, tracker, event))
+	req = <-requests
+	require.NoError(t, json.Unmarshal(req.body, &ce))
+	assert.Equal(t, "k8s.ddukbg.resourcetracker.ready", ce.Type)
+	assert.Len(t, slack.events, 1)
+}
+
+func TestCloudEventsBinary(t *testing.T) {
+	server, requests := newCloudEventsReceiver(t)
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				CloudEvents: &ddukbgv1alpha1.CloudEventsConfig{
+					SinkURL: server.URL,
+					Mode:    CloudEventsBinary,
+					Source:  "//cluster/prod",
+				},
+			},
+		},
+	}
+	event := NotificationEvent{ID: "3f2a", Type: EventImageChanged, Kind: "StatefulSet", Namespace: "default",
+		Name: "db", Images: []string{"postgres:16"}, PreviousImages: []string{"postgres:15"}}
+	notifier := &CloudEventsNotifier{}
+	require.NoError(t, notifier.Notify(context.Background(), tracker, event))
+
+	req := <-requests
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "1.0", req.header.Get("ce-specversion"))
+	assert.Equal(t, "3f2a", req.header.Get("ce-id"))
+	assert.Equal(t, "//cluster/prod", req.header.Get("ce-source"))
+	assert.Equal(t, "k8s.ddukbg.resourcetracker.image-changed", req.header.Get("ce-type"))
+	assert.Equal(t, "StatefulSet/default/db", req.header.Get("ce-subject"))
+	assert.Empty(t, req.header.Get("ce-time"))
+
+	var data NotificationEvent
+	require.NoError(t, json.Unmarshal(req.body, &data))
+	assert.Equal(t, []string{"postgres:16"}, data.Images)
+
+	tracker.Spec.Notify.CloudEvents = &ddukbgv1alpha1.CloudEventsConfig{}
+	assert.Error(t, notifier.Notify(context.Background(), tracker, event), "a sink is required")
+}
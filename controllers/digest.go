@@ -0,0 +1,237 @@
+// controllers/digest.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// EventDigest is the type of the message batching the transitions of a digest window
+const EventDigest = "digest"
+
+// DigestBuffer collects the notifications of namespace-wide trackers during their
+// digest window, so that e.g. a node drain is reported in one message instead of
+// one message per resource. It also holds back notifications during quiet hours
+// when there is no outbox to persist them in.
+type DigestBuffer struct {
+	mu      sync.Mutex
+	pending map[digestKey]*pendingDigest
+}
+
+// digestKey identifies an open digest window; group separates windows with
+// different purposes, such as digest windows and quiet hours
+type digestKey struct {
+	tracker types.NamespacedName
+	group   string
+}
+
+// pendingDigest holds the events of an open digest window and the channels they were routed to
+type pendingDigest struct {
+	events   []NotificationEvent
+	channels map[string]bool
+}
+
+// NewDigestBuffer creates an empty digest buffer
+func NewDigestBuffer() *DigestBuffer {
+	return &DigestBuffer{pending: make(map[digestKey]*pendingDigest)}
+}
+
+// Add adds the event to the tracker's digest of the group. The first event opens
+// the window, and flush is called with the collected events when it closes.
+func (b *DigestBuffer) Add(tracker types.NamespacedName, group string, window time.Duration, event NotificationEvent,
+	channels []string, flush func(tracker types.NamespacedName, events []NotificationEvent, channels map[string]bool)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := digestKey{tracker: tracker, group: group}
+	digest, open := b.pending[key]
+	if !open {
+		digest = &pendingDigest{channels: make(map[string]bool)}
+		b.pending[key] = digest
+		time.AfterFunc(window, func() {
+			b.mu.Lock()
+			delete(b.pending, key)
+			b.mu.Unlock()
+			flush(tracker, digest.events, digest.channels)
+		})
+	}
+	digest.events = append(digest.events, event)
+	for _, channel := range channels {
+		digest.channels[channel] = true
+	}
+}
+
+// digestWindow returns the tracker's digest window, or 0 when its notifications are not batched
+func digestWindow(tracker *ddukbgv1alpha1.ResourceTracker) time.Duration {
+	digest := tracker.Spec.Notify.Digest
+	if singleResource(tracker.Spec.Target) || digest == nil {
+		return 0
+	}
+	return digest.Window.Duration
+}
+
+// digests returns the configured digest buffer, creating one on first use
+func (r *ResourceTrackerReconciler) digests() *DigestBuffer {
+	if r.Digests == nil {
+		r.Digests = NewDigestBuffer()
+	}
+	return r.Digests
+}
+
+// sendDigest sends the events of a closed digest window, together with the
+// readiness of every resource the tracker watches, to the channels they were routed to
+func (r *ResourceTrackerReconciler) sendDigest(trackerKey types.NamespacedName, events []NotificationEvent, channels map[string]bool) {
+	ctx := context.Background()
+	logger := log.FromContext(ctx).WithValues("tracker", trackerKey)
+
+	// 창이 열려 있는 동안 갱신된 최신 상태로 요약
+	tracker := &ddukbgv1alpha1.ResourceTracker{}
+	if err := r.Get(ctx, trackerKey, tracker); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to get ResourceTracker for digest")
+		}
+		return
+	}
+
+	event := digestEvent(tracker, events)
+	event.Message = renderMessage(ctx, tracker, event)
+
+	registry := r.notifiers()
+	for _, channel := range registry.Channels() {
+		if !channels[channel] {
+			continue
+		}
+		notifier, _ := registry.Get(channel)
+		if !notifier.Enabled(tracker) {
+			continue
+		}
+		if err := r.dispatch(ctx, r.notificationJob(tracker, channel, notifier, event)); err != nil {
+			logger.Error(err, "Failed to send digest", "channel", channel)
+		}
+	}
+}
+
+// hold records the event held back by quiet hours in the outbox for each channel.
+// The outbox releases it in a digest once the quiet hours ended, even after a
+// controller restart. Within a Reconcile it is persisted with the Reconcile's
+// notifications; otherwise it is written right away.
+func (r *ResourceTrackerReconciler) hold(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	event NotificationEvent, channels []string, until time.Time) error {
+	trackerKey := client.ObjectKeyFromObject(tracker)
+	event.ID = eventID(trackerKey, event)
+	batch := outboxBatchFrom(ctx)
+
+	var keys []string
+	for _, channel := range channels {
+		key := event.ID + "." + channel
+		added, err := r.Outbox.Add(ctx, OutboxEntry{
+			Key:       key,
+			Channel:   channel,
+			Tracker:   trackerKey.String(),
+			Event:     event,
+			CreatedAt: time.Now(),
+			HeldUntil: &until,
+		})
+		if err != nil {
+			if batch != nil {
+				batch.fail(err)
+			}
+			return fmt.Errorf("failed to hold notification in the outbox: %w", err)
+		}
+		if !added {
+			continue
+		}
+		if batch != nil {
+			batch.add(key, nil)
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := r.Outbox.Persist(ctx, keys); err != nil {
+		r.Outbox.Discard(keys)
+		return err
+	}
+	return nil
+}
+
+// releaseHeld sends the events of one tracker and channel held back by quiet hours
+// in a digest, once the quiet hours ended
+func (r *ResourceTrackerReconciler) releaseHeld(ctx context.Context, entries []OutboxEntry) error {
+	namespace, name, _ := strings.Cut(entries[0].Tracker, "/")
+	tracker := &ddukbgv1alpha1.ResourceTracker{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, tracker); err != nil {
+		// 트래커가 사라졌으면 전송할 곳이 없음
+		return client.IgnoreNotFound(err)
+	}
+	notifier, ok := r.notifiers().Get(entries[0].Channel)
+	if !ok || !notifier.Enabled(tracker) {
+		return nil
+	}
+
+	events := make([]NotificationEvent, 0, len(entries))
+	for _, entry := range entries {
+		events = append(events, entry.Event)
+	}
+	event := digestEvent(tracker, events)
+	// 다시 보내게 되어도 같은 다이제스트로 식별되도록 조용한 시간이 끝난 시각을 사용
+	event.Timestamp = *entries[0].HeldUntil
+	event.Message = renderMessage(ctx, tracker, event)
+	return r.dispatch(ctx, r.notificationJob(tracker, entries[0].Channel, notifier, event))
+}
+
+// digestEvent builds the digest of the batched events, counting the ready and
+// progressing resources from the tracker status, including those never ready
+func digestEvent(tracker *ddukbgv1alpha1.ResourceTracker, events []NotificationEvent) NotificationEvent {
+	tracked := trackedKeys(tracker)
+	event := NotificationEvent{
+		Type:          EventDigest,
+		Severity:      SeverityInfo,
+		Kind:          tracker.Spec.Target.Kind,
+		Namespace:     describeNamespaces(tracker.Spec.Target),
+		TotalReplicas: int32(len(tracked)),
+		Events:        events,
+		Count:         len(events),
+		Timestamp:     time.Now(),
+	}
+	for _, e := range events {
+		if severityRank(e.Severity) > severityRank(event.Severity) {
+			event.Severity = e.Severity
+		}
+	}
+	for key := range tracked {
+		if tracker.Status.ResourceStatus[key] {
+			event.ReadyReplicas++
+			continue
+		}
+		_, name, _ := strings.Cut(key, "/")
+		event.Progressing = append(event.Progressing, name)
+	}
+	sort.Strings(event.Progressing)
+	return event
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
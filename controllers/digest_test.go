@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestMessage(t *testing.T) {
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "Deployment", Namespace: "default"},
+		},
+		Status: ddukbgv1alpha1.ResourceTrackerStatus{
+			ResourceStatus: map[string]bool{
+				"default/web": true, "default/db": true, "default/cache": true,
+				"default/worker": false, "default/api": false,
+			},
+		},
+	}
+	events := []NotificationEvent{
+		{Type: EventReady, Severity: SeverityInfo, Name: "web"},
+		{Type: EventFailed, Severity: SeverityCritical, Name: "api", Reason: "ProgressDeadlineExceeded"},
+		{Type: EventImageChanged, Severity: SeverityInfo, Name: "worker", Images: []string{"worker:v2"}},
+		{Type: EventScaled, Severity: SeverityInfo, Name: "db", PreviousReplicas: 1, TotalReplicas: 3},
+		{Type: EventDeleted, Severity: SeverityWarning, Name: "old"},
+	}
+
+	event := digestEvent(tracker, events)
+	assert.Equal(t, SeverityCritical, event.Severity)
+	assert.Equal(t, 5, event.Count)
+	assert.Equal(t, []string{"api", "worker"}, event.Progressing)
+	assert.Equal(t, "3/5 Deployments ready in default; api and worker still progressing\n"+
+		"> web became ready\n"+
+		"> api failed: ProgressDeadlineExceeded\n"+
+		"> worker image changed to worker:v2\n"+
+		"> db scaled 1 → 3\n"+
+		"> old was deleted", renderMessage(context.Background(), tracker, event))
+
+	assert.Equal(t, "", listNames(nil))
+	assert.Equal(t, "api", listNames([]string{"api"}))
+	assert.Equal(t, "api, web and worker", listNames([]string{"api", "web", "worker"}))
+
+	// 한 번도 Ready가 아니었던 리소스는 TransitionTimes에만 있음
+	tracker.Status.TransitionTimes = map[string]metav1.Time{"default/web": metav1.Now(), "default/migrate": metav1.Now()}
+	event = digestEvent(tracker, events)
+	assert.Equal(t, int32(3), event.ReadyReplicas)
+	assert.Equal(t, int32(6), event.TotalReplicas)
+	assert.Equal(t, []string{"api", "migrate", "worker"}, event.Progressing)
+}
+
+func TestSendNotificationsDigest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "Pod", Namespace: "apps"},
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				Digest: &ddukbgv1alpha1.DigestConfig{Window: metav1.Duration{Duration: 50 * time.Millisecond}},
+			},
+		},
+		Status: ddukbgv1alpha1.ResourceTrackerStatus{
+			ResourceStatus: map[string]bool{"apps/web-0": true, "apps/web-1": true, "apps/web-2": false},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tracker).WithStatusSubresource(tracker).Build()
+
+	slack := newFakeNotifier()
+	pagerDuty := newFakeNotifier()
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, slack)
+	registry.Register(ChannelPagerDuty, pagerDuty)
+	r := &ResourceTrackerReconciler{Client: c, Notifiers: registry}
+
+	ctx := context.Background()
+	for _, name := range []string{"web-0", "web-1"} {
+		event := NotificationEvent{Type: EventReady, Kind: "Pod", Namespace: "apps", Name: name}
+		require.NoError(t, r.sendNotifications(ctx, tracker, event))
+	}
+	failed := NotificationEvent{Type: EventFailed, Kind: "Pod", Namespace: "apps", Name: "web-2", Reason: "CrashLoopBackOff"}
+	require.NoError(t, r.sendNotifications(ctx, tracker, failed))
+
+	assert.Len(t, pagerDuty.events, 3, "incident channels receive every event")
+	assert.Empty(t, slack.events)
+
+	select {
+	case digest := <-slack.events:
+		assert.Equal(t, EventDigest, digest.Type)
+		assert.Equal(t, "2/3 Pods ready in apps; web-2 still progressing\n"+
+			"> web-0 became ready\n"+
+			"> web-1 became ready\n"+
+			"> web-2 failed: CrashLoopBackOff", digest.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("digest was not sent")
+	}
+	assert.Empty(t, slack.events, "one digest per window")
+
+	// 단일 리소스 트래커는 묶지 않음
+	tracker.Spec.Target.Name = "web-0"
+	require.NoError(t, r.sendNotifications(ctx, tracker, NotificationEvent{Type: EventReady, Kind: "Pod", Namespace: "apps", Name: "web-0"}))
+	assert.Len(t, slack.events, 1)
+}
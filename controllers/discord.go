@@ -0,0 +1,75 @@
+// controllers/discord.go
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// discordDescriptionLimit is the maximum embed description length
+const discordDescriptionLimit = 4096
+
+// DiscordMessage is the payload of a Discord webhook
+type DiscordMessage struct {
+	Embeds []DiscordEmbed `json:"embeds"`
+}
+
+// DiscordEmbed is a rich embed with a colour bar
+type DiscordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url,omitempty"`
+	Color       int    `json:"color"`
+	Timestamp   string `json:"timestamp,omitempty"`
+}
+
+// DiscordNotifier posts notifications to a Discord webhook
+type DiscordNotifier struct {
+	// Client reads the Secret holding the webhook URL
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *DiscordNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.Discord != nil
+}
+
+// Notify implements Notifier
+func (n *DiscordNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	webhookURL, err := secretValue(ctx, n.Client, tracker.Namespace, &tracker.Spec.Notify.Discord.URLSecretRef)
+	if err != nil {
+		return err
+	}
+
+	embed := DiscordEmbed{
+		Title:       eventTitle(event),
+		Description: truncate(event.Message, discordDescriptionLimit),
+		Color:       discordColor(event),
+	}
+	if !event.Timestamp.IsZero() {
+		embed.Timestamp = event.Timestamp.UTC().Format(time.RFC3339)
+	}
+	// 잘못된 대시보드 템플릿은 링크만 생략
+	if url, err := dashboardURL(tracker, event); err == nil {
+		embed.URL = url
+	}
+
+	return postJSON(ctx, ChannelDiscord, webhookURL, DiscordMessage{Embeds: []DiscordEmbed{embed}})
+}
+
+// discordColor is the embed colour of the event, matching the Slack colour bar
+func discordColor(event NotificationEvent) int {
+	switch event.Type {
+	case EventReady, EventCompleted, EventRolloutPromoted:
+		return 0x2EB67D
+	case EventFailed, EventRolloutAborted:
+		return 0xE01E5A
+	default:
+		return 0xECB22E
+	}
+}
@@ -0,0 +1,269 @@
+// controllers/email.go
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+const (
+	defaultSMTPPort = 587
+	smtpTimeout     = 10 * time.Second
+
+	smtpTLSStartTLS = "starttls"
+	smtpTLSImplicit = "tls"
+)
+
+// emailConfig holds everything needed to deliver one notification email
+type emailConfig struct {
+	Host     string
+	Port     int
+	TLS      string
+	From     string
+	To       []string
+	Username string
+	Password string
+}
+
+var emailHTMLTemplate = template.Must(template.New("email").Parse(`<html>
+<body style="font-family: sans-serif;">
+<h3>{{.Title}}</h3>
+{{- if .Details}}
+<ul>
+{{- range .Details}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
+
+// EmailNotifier sends notifications by email through the tracker's SMTP server
+type EmailNotifier struct {
+	// Client reads the SMTP credentials Secret
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *EmailNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.Email != ""
+}
+
+// Notify implements Notifier
+func (n *EmailNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	cfg, err := emailConfigFor(ctx, n.Client, tracker)
+	if err != nil {
+		return err
+	}
+	return sendEmail(cfg, event.Message)
+}
+
+// sendEmail sends the message as a plain text + HTML email
+func sendEmail(cfg emailConfig, message string) error {
+	msg, err := buildEmailMessage(cfg.From, cfg.To, message)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %v", err)
+	}
+
+	if err := deliverEmail(cfg, msg); err != nil {
+		return fmt.Errorf("failed to send email notification: %w", err)
+	}
+	return nil
+}
+
+// emailConfigFor resolves the tracker's SMTP settings and credentials Secret
+func emailConfigFor(ctx context.Context, c client.Reader, tracker *ddukbgv1alpha1.ResourceTracker) (emailConfig, error) {
+	smtpSpec := tracker.Spec.Notify.SMTP
+	if smtpSpec == nil || smtpSpec.Host == "" {
+		return emailConfig{}, fmt.Errorf("notify.smtp.host is required for email notifications")
+	}
+
+	cfg := emailConfig{
+		Host: smtpSpec.Host,
+		Port: smtpSpec.Port,
+		TLS:  smtpSpec.TLS,
+		From: smtpSpec.From,
+		To:   splitRecipients(tracker.Spec.Notify.Email),
+	}
+	if cfg.Port == 0 {
+		cfg.Port = defaultSMTPPort
+	}
+	if cfg.TLS == "" {
+		cfg.TLS = smtpTLSStartTLS
+	}
+	if len(cfg.To) == 0 {
+		return emailConfig{}, fmt.Errorf("no email recipients configured")
+	}
+
+	if smtpSpec.CredentialsSecret != "" {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{
+			Name:      smtpSpec.CredentialsSecret,
+			Namespace: tracker.Namespace,
+		}, secret); err != nil {
+			return emailConfig{}, fmt.Errorf("failed to get SMTP credentials secret: %v", err)
+		}
+		cfg.Username = string(secret.Data["username"])
+		cfg.Password = string(secret.Data["password"])
+	}
+
+	return cfg, nil
+}
+
+// splitRecipients parses a comma separated address list
+func splitRecipients(list string) []string {
+	var recipients []string
+	for _, addr := range strings.Split(list, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			recipients = append(recipients, addr)
+		}
+	}
+	return recipients
+}
+
+// buildEmailMessage renders a multipart/alternative message from the notification text.
+// 첫 줄은 제목으로, "> " 로 시작하는 나머지 줄은 상세 항목으로 사용
+func buildEmailMessage(from string, to []string, message string) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	title := strings.Trim(lines[0], "* ")
+	var details []string
+	for _, line := range lines[1:] {
+		if line = strings.TrimSpace(strings.TrimPrefix(line, ">")); line != "" {
+			details = append(details, line)
+		}
+	}
+
+	var htmlBody bytes.Buffer
+	if err := emailHTMLTemplate.Execute(&htmlBody, struct {
+		Title   string
+		Details []string
+	}{title, details}); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	parts := []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=UTF-8", []byte(message)},
+		{"text/html; charset=UTF-8", htmlBody.Bytes()},
+	}
+	for _, p := range parts {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(p.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", mw.Boundary())
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// deliverEmail opens the SMTP session according to the TLS mode and sends msg
+func deliverEmail(cfg emailConfig, msg []byte) error {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	tlsConfig := &tls.Config{ServerName: cfg.Host}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	if cfg.TLS == smtpTLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(smtpTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if cfg.TLS == smtpTLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", addr)
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(cfg.From); err != nil {
+		return err
+	}
+	for _, rcpt := range cfg.To {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
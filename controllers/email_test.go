@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// receivedEmail is what the SMTP stand-in captured for a single session
+type receivedEmail struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// startFakeSMTPServer runs a minimal plain-text SMTP server on localhost
+func startFakeSMTPServer(t *testing.T, extensions ...string) (string, int, <-chan receivedEmail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan receivedEmail, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, extensions, received)
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return "127.0.0.1", addr.Port, received
+}
+
+func serveSMTP(conn net.Conn, extensions []string, received chan<- receivedEmail) {
+	defer conn.Close()
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	reply := func(line string) {
+		rw.WriteString(line + "\r\n")
+		rw.Flush()
+	}
+
+	var mail receivedEmail
+	reply("220 localhost ESMTP")
+	for {
+		line, err := rw.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch cmd {
+		case "EHLO":
+			for _, ext := range extensions {
+				reply("250-" + ext)
+			}
+			reply("250 localhost")
+		case "AUTH":
+			decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(line, "AUTH PLAIN "))
+			mail.auth = string(decoded)
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			mail.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			reply("250 OK")
+		case "RCPT":
+			mail.to = append(mail.to, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := rw.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			mail.data = data.String()
+			reply("250 OK")
+			received <- mail
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestEmailNotification(t *testing.T) {
+	host, port, received := startFakeSMTPServer(t, "AUTH PLAIN")
+
+	cfg := emailConfig{
+		Host:     host,
+		Port:     port,
+		TLS:      "none",
+		From:     "watcher@example.com",
+		To:       []string{"oncall@example.com", "team@example.com"},
+		Username: "watcher",
+		Password: "secret",
+	}
+	message := readyMessage("Deployment", "default", "test-app", 3, 3)
+
+	require.NoError(t, sendEmail(cfg, message))
+
+	mail := <-received
+	assert.Equal(t, "\x00watcher\x00secret", mail.auth)
+	assert.Equal(t, "watcher@example.com", mail.from)
+	assert.Equal(t, []string{"oncall@example.com", "team@example.com"}, mail.to)
+	assert.Contains(t, mail.data, "Subject: Deployment default/test-app is now ready")
+	assert.Contains(t, mail.data, "Content-Type: multipart/alternative")
+	assert.Contains(t, mail.data, "Content-Type: text/plain; charset=UTF-8")
+	assert.Contains(t, mail.data, "Content-Type: text/html; charset=UTF-8")
+	assert.Contains(t, mail.data, "<li>Replicas: 3/3 ready</li>")
+}
+
+func TestEmailNotificationRequiresStartTLS(t *testing.T) {
+	host, port, _ := startFakeSMTPServer(t)
+
+	cfg := emailConfig{
+		Host: host,
+		Port: port,
+		TLS:  "starttls",
+		From: "watcher@example.com",
+		To:   []string{"oncall@example.com"},
+	}
+
+	err := sendEmail(cfg, "test message")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support STARTTLS")
+}
+
+func TestEmailConfigFromSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp-credentials", Namespace: "default"},
+		Data: map[string][]byte{
+			"username": []byte("watcher"),
+			"password": []byte("secret"),
+		},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				Email: "oncall@example.com, team@example.com",
+				SMTP: &ddukbgv1alpha1.SMTPConfig{
+					Host:              "smtp.example.com",
+					From:              "watcher@example.com",
+					CredentialsSecret: "smtp-credentials",
+				},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+	cfg, err := emailConfigFor(context.Background(), c, tracker)
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)))
+	assert.Equal(t, "starttls", cfg.TLS)
+	assert.Equal(t, []string{"oncall@example.com", "team@example.com"}, cfg.To)
+	assert.Equal(t, "watcher", cfg.Username)
+	assert.Equal(t, "secret", cfg.Password)
+
+	// SMTP 설정 없이 이메일만 지정한 경우
+	tracker.Spec.Notify.SMTP = nil
+	_, err = emailConfigFor(context.Background(), c, tracker)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+// controllers/eventbus.go
+
+package controllers
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// Message bus channel types, configured for the whole controller
+const (
+	ChannelNATS  = "nats"
+	ChannelKafka = "kafka"
+)
+
+// eventBusChannels publish the transitions of every tracker; the tracker's
+// routes do not apply to them
+var eventBusChannels = map[string]bool{
+	ChannelNATS:  true,
+	ChannelKafka: true,
+}
+
+// EventBusConfig is the controller-level configuration of the message buses
+// every transition is published to
+type EventBusConfig struct {
+	NATS  *NATSConfig  `json:"nats,omitempty"`
+	Kafka *KafkaConfig `json:"kafka,omitempty"`
+}
+
+// NATSConfig defines the NATS server and subject events are published to
+type NATSConfig struct {
+	// URL of the server, e.g. nats://nats.nats:4222. Several URLs may be separated by commas.
+	URL string `json:"url"`
+
+	// Subject the events are published to
+	Subject string `json:"subject"`
+
+	// JetStream publishes to a stream capturing the subject and waits for its
+	// acknowledgement, using the event ID for the stream's duplicate detection.
+	// Only JetStream delivers at least once: core NATS does not store messages, so
+	// subscribers that are not connected when an event is published never see it.
+	JetStream bool `json:"jetStream,omitempty"`
+
+	// CredentialsFile is a NATS user credentials (.creds) file
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+}
+
+// KafkaConfig defines the Kafka REST proxy and topic events are produced to
+type KafkaConfig struct {
+	// RESTProxyURL of a proxy implementing the Confluent REST Proxy v2 API, such as
+	// the Confluent REST Proxy, the Redpanda HTTP Proxy or the Strimzi Kafka Bridge.
+	// Credentials for basic authentication may be given in the URL.
+	RESTProxyURL string `json:"restProxyURL"`
+
+	// Topic the events are produced to, keyed by namespace/name
+	Topic string `json:"topic"`
+}
+
+// EventRecord is the JSON record published to message buses
+type EventRecord struct {
+	// Tracker is the namespace/name of the ResourceTracker that detected the transition
+	Tracker string `json:"tracker"`
+	NotificationEvent
+}
+
+// LoadEventBusConfig reads the message bus configuration file
+func LoadEventBusConfig(path string) (*EventBusConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &EventBusConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid event bus config %s: %w", path, err)
+	}
+	if cfg.NATS != nil && (cfg.NATS.URL == "" || cfg.NATS.Subject == "") {
+		return nil, fmt.Errorf("invalid event bus config %s: nats requires url and subject", path)
+	}
+	if cfg.Kafka != nil && (cfg.Kafka.RESTProxyURL == "" || cfg.Kafka.Topic == "") {
+		return nil, fmt.Errorf("invalid event bus config %s: kafka requires restProxyURL and topic", path)
+	}
+	return cfg, nil
+}
+
+// eventRecord builds the bus record of an event detected by the tracker
+func eventRecord(tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) EventRecord {
+	return EventRecord{
+		Tracker:           types.NamespacedName{Namespace: tracker.Namespace, Name: tracker.Name}.String(),
+		NotificationEvent: event,
+	}
+}
+
+// recordKey returns the namespace/name of the resource, or of the tracker for
+// events without a resource, so that the records of a resource stay in order
+func recordKey(tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) string {
+	if event.Name == "" {
+		return types.NamespacedName{Namespace: tracker.Namespace, Name: tracker.Name}.String()
+	}
+	return event.Namespace + "/" + event.Name
+}
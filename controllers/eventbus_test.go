@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/nats-io/nats-server/v2/server"
+	natstest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runNATSServer starts an embedded NATS server with JetStream enabled
+func runNATSServer(t *testing.T) *server.Server {
+	opts := natstest.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	srv := natstest.RunServer(&opts)
+	t.Cleanup(srv.Shutdown)
+	return srv
+}
+
+func TestNATSNotifier(t *testing.T) {
+	srv := runNATSServer(t)
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+	}
+	event := NotificationEvent{ID: "3f2a", Type: EventReady, Kind: "Deployment", Namespace: "default", Name: "api",
+		ReadyReplicas: 3, TotalReplicas: 3}
+	ctx := context.Background()
+
+	consumer, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	defer consumer.Close()
+	sub, err := consumer.SubscribeSync("deploys.core")
+	require.NoError(t, err)
+	require.NoError(t, consumer.Flush())
+
+	core, err := NewNATSNotifier(&NATSConfig{URL: srv.ClientURL(), Subject: "deploys.core"})
+	require.NoError(t, err)
+	defer core.Close()
+	require.NoError(t, core.Notify(ctx, tracker, event))
+
+	msg, err := sub.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	var record EventRecord
+	require.NoError(t, json.Unmarshal(msg.Data, &record))
+	assert.Equal(t, "default/test-tracker", record.Tracker)
+	assert.Equal(t, EventReady, record.Type)
+	assert.Equal(t, "3f2a", record.ID)
+	assert.Equal(t, int32(3), record.ReadyReplicas)
+
+	js, err := NewNATSNotifier(&NATSConfig{URL: srv.ClientURL(), Subject: "deploys.events", JetStream: true})
+	require.NoError(t, err)
+	defer js.Close()
+	// 주제를 저장하는 스트림이 없으면 확인 응답이 없으므로 실패로 처리되어 재시도됨
+	assert.Error(t, js.Notify(ctx, tracker, event), "unacknowledged publishes fail so that they are retried")
+
+	stream, err := jetstream.New(consumer)
+	require.NoError(t, err)
+	deploys, err := stream.CreateStream(ctx, jetstream.StreamConfig{
+		Name: "DEPLOYS", Subjects: []string{"deploys.events"}, Duplicates: time.Minute})
+	require.NoError(t, err)
+	require.NoError(t, js.Notify(ctx, tracker, event), "the publish is acknowledged by the stream")
+	require.NoError(t, js.Notify(ctx, tracker, event), "a redelivery is acknowledged too")
+
+	info, err := deploys.Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), info.State.Msgs, "the stream drops redelivered events")
+	stored, err := deploys.GetMsg(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "3f2a", stored.Header.Get(jetstream.MsgIDHeader))
+	require.NoError(t, json.Unmarshal(stored.Data, &record))
+	assert.Equal(t, "default/test-tracker", record.Tracker)
+}
+
+func TestKafkaNotifier(t *testing.T) {
+	type produced struct {
+		path    string
+		header  http.Header
+		request kafkaProduceRequest
+	}
+	requests := make(chan produced, 1)
+	var fail atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body kafkaProduceRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests <- produced{path: r.URL.Path, header: r.Header, request: body}
+		w.Header().Set("Content-Type", "application/vnd.kafka.v2+json")
+		if fail.Load() {
+			fmt.Fprint(w, `{"offsets":[{"partition":null,"offset":null,"error_code":50003,"error":"leader not available"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"offsets":[{"partition":2,"offset":41,"error_code":null,"error":null}]}`)
+	}))
+	defer server.Close()
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+	}
+	event := NotificationEvent{ID: "3f2a", Type: EventFailed, Kind: "Deployment", Namespace: "prod", Name: "api",
+		Reason: "ProgressDeadlineExceeded"}
+	notifier := NewKafkaNotifier(&KafkaConfig{RESTProxyURL: server.URL + "/", Topic: "deploy-events"})
+	require.NoError(t, notifier.Notify(context.Background(), tracker, event))
+
+	req := <-requests
+	assert.Equal(t, "/topics/deploy-events", req.path)
+	assert.Equal(t, kafkaJSONContentType, req.header.Get("Content-Type"))
+	require.Len(t, req.request.Records, 1)
+	assert.Equal(t, "prod/api", req.request.Records[0].Key)
+	assert.Equal(t, "default/test-tracker", req.request.Records[0].Value.Tracker)
+	assert.Equal(t, "ProgressDeadlineExceeded", req.request.Records[0].Value.Reason)
+
+	fail.Store(true)
+	err := notifier.Notify(context.Background(), tracker, event)
+	<-requests
+	assert.ErrorContains(t, err, "leader not available", "records rejected by the proxy are retried")
+}
+
+func TestEventBusIgnoresRoutes(t *testing.T) {
+	slack := newFakeNotifier()
+	bus := newFakeNotifier()
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, slack)
+	registry.Register(ChannelKafka, bus)
+	r := &ResourceTrackerReconciler{Notifiers: registry}
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				Routes: []ddukbgv1alpha1.NotificationRoute{
+					{Match: ddukbgv1alpha1.RouteMatch{Events: []string{EventFailed}}, Channels: []string{ChannelSlack}},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for _, eventType := range []string{EventReady, EventNotReady} {
+		event := NotificationEvent{Type: eventType, Kind: "Deployment", Namespace: "default", Name: "api"}
+		require.NoError(t, r.sendNotifications(ctx, tracker, event))
+	}
+	assert.Empty(t, slack.events)
+	require.Len(t, bus.events, 2, "every transition is published")
+	assert.Equal(t, EventReady, (<-bus.events).Type)
+	assert.Equal(t, EventNotReady, (<-bus.events).Type)
+}
+
+func TestLoadEventBusConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, fmt.Sprintf("bus-%d.yaml", time.Now().UnixNano()))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	cfg, err := LoadEventBusConfig(write(`
+nats:
+  url: nats://nats.nats:4222
+  subject: deploys.events
+  jetStream: true
+kafka:
+  restProxyURL: http://kafka-rest:8082
+  topic: deploy-events
+`))
+	require.NoError(t, err)
+	assert.True(t, cfg.NATS.JetStream)
+	assert.Equal(t, "deploy-events", cfg.Kafka.Topic)
+
+	_, err = LoadEventBusConfig(write("nats:\n  url: nats://nats:4222\n"))
+	assert.Error(t, err, "a subject is required")
+	_, err = LoadEventBusConfig(write("kafka:\n  brokers: [kafka:9092]\n"))
+	assert.Error(t, err, "unknown fields are rejected")
+}
@@ -0,0 +1,163 @@
+// controllers/failures.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// rolloutStuckTimeout is how long a StatefulSet or DaemonSet may stay not ready
+// before it is reported as failed. It matches the default Deployment progress deadline.
+const rolloutStuckTimeout = 10 * time.Minute
+
+// 실패로 간주하는 컨테이너 대기 사유
+var failedWaitingReasons = map[string]bool{
+	"CrashLoopBackOff": true,
+	"ImagePullBackOff": true,
+	"ErrImagePull":     true,
+}
+
+// deploymentFailure reports why a Deployment rollout failed, or "" if it has not
+func deploymentFailure(deploy *appsv1.Deployment) (reason, detail string) {
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing &&
+			cond.Status == corev1.ConditionFalse &&
+			cond.Reason == "ProgressDeadlineExceeded" {
+			return cond.Reason, cond.Message
+		}
+	}
+	return "", ""
+}
+
+// statefulSetFailure reports a StatefulSet whose pods have been stuck not ready
+// for longer than rolloutStuckTimeout
+func statefulSetFailure(sts *appsv1.StatefulSet, isReady bool, notReadySince metav1.Time) (reason, detail string) {
+	if isReady || notReadySince.IsZero() {
+		return "", ""
+	}
+	stuckFor := time.Since(notReadySince.Time)
+	if stuckFor < rolloutStuckTimeout {
+		return "", ""
+	}
+	return "PodsNotReady", fmt.Sprintf("%d/%d pods ready for %s",
+		sts.Status.ReadyReplicas, *sts.Spec.Replicas, stuckFor.Round(time.Second))
+}
+
+// daemonSetFailure reports a DaemonSet whose pods have been stuck not ready
+// for longer than rolloutStuckTimeout
+func daemonSetFailure(ds *appsv1.DaemonSet, isReady bool, notReadySince metav1.Time) (reason, detail string) {
+	if isReady || notReadySince.IsZero() {
+		return "", ""
+	}
+	stuckFor := time.Since(notReadySince.Time)
+	if stuckFor < rolloutStuckTimeout {
+		return "", ""
+	}
+	return "PodsNotReady", fmt.Sprintf("%d/%d pods ready, %d unavailable for %s",
+		ds.Status.NumberReady, ds.Status.DesiredNumberScheduled, ds.Status.NumberUnavailable, stuckFor.Round(time.Second))
+}
+
+// podFailure reports a Pod that failed or has a container stuck in a failing state
+func podFailure(pod *corev1.Pod) (reason, detail string) {
+	if pod.Status.Phase == corev1.PodFailed {
+		reason = pod.Status.Reason
+		if reason == "" {
+			reason = string(corev1.PodFailed)
+		}
+		return reason, pod.Status.Message
+	}
+
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.State.Waiting != nil && failedWaitingReasons[cs.State.Waiting.Reason] {
+			return cs.State.Waiting.Reason, fmt.Sprintf("container %s: %s", cs.Name, cs.State.Waiting.Message)
+		}
+	}
+	return "", ""
+}
+
+// recordTransition stores when the resource's readiness last changed.
+// It returns true if the tracker status was modified.
+func recordTransition(tracker *ddukbgv1alpha1.ResourceTracker, key string, isReady bool) bool {
+	_, seen := tracker.Status.TransitionTimes[key]
+	if seen && tracker.Status.ResourceStatus[key] == isReady {
+		return false
+	}
+	if tracker.Status.TransitionTimes == nil {
+		tracker.Status.TransitionTimes = make(map[string]metav1.Time)
+	}
+	tracker.Status.TransitionTimes[key] = metav1.Now()
+	return true
+}
+
+// updateFailureStatus records a failure (or its recovery when reason is empty) and,
+// when AlertOnFail is set, sends a failure alert on a new failure and a recovery
+// event when a recorded failure clears.
+// It returns true if the tracker status was modified.
+func (r *ResourceTrackerReconciler) updateFailureStatus(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	event NotificationEvent, reason, detail string) bool {
+	key := fmt.Sprintf("%s/%s", event.Namespace, event.Name)
+	// 실패와 복구가 반복되어도 전환마다 다른 이벤트가 되도록 직전 전환 시각을 기록
+	event.PreviousTransition = tracker.Status.FailureTimes[key].Time
+
+	if reason == "" {
+		previous, failed := tracker.Status.Failures[key]
+		if !failed {
+			return false
+		}
+		// 실패 중에도 Running인 Pod는 Ready 전환이 없으므로 인시던트를 닫도록 복구를 따로 알림.
+		// 인시던트 채널이 실패 기록을 확인할 수 있도록 기록을 지우기 전에 전송
+		if tracker.Spec.Notify.AlertOnFail {
+			event.Type = EventRecovered
+			event.Reason = previous
+			event.Detail = ""
+			if err := r.sendNotifications(ctx, tracker, event); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to send recovery notification")
+			}
+		}
+		delete(tracker.Status.Failures, key)
+		recordFailureTime(tracker, key)
+		return true
+	}
+
+	if tracker.Status.Failures[key] == reason {
+		return false
+	}
+	if tracker.Status.Failures == nil {
+		tracker.Status.Failures = make(map[string]string)
+	}
+	tracker.Status.Failures[key] = reason
+	recordFailureTime(tracker, key)
+
+	r.Recorder.Event(tracker, corev1.EventTypeWarning, event.Kind+"Failed",
+		fmt.Sprintf("%s %s failed: %s", event.Kind, key, reason))
+
+	if tracker.Spec.Notify.AlertOnFail {
+		event.Type = EventFailed
+		event.Phase = "Failed"
+		event.Reason = reason
+		event.Detail = detail
+		if err := r.sendNotifications(ctx, tracker, event); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to send failure notification")
+		}
+	}
+	return true
+}
+
+// recordFailureTime stores when the resource started or stopped failing
+func recordFailureTime(tracker *ddukbgv1alpha1.ResourceTracker, key string) {
+	if tracker.Status.FailureTimes == nil {
+		tracker.Status.FailureTimes = make(map[string]metav1.Time)
+	}
+	tracker.Status.FailureTimes[key] = metav1.Now()
+}
@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		status corev1.PodStatus
+		reason string
+	}{
+		{
+			name:   "Running",
+			status: corev1.PodStatus{Phase: corev1.PodRunning},
+			reason: "",
+		},
+		{
+			name:   "Failed",
+			status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"},
+			reason: "Evicted",
+		},
+		{
+			name: "CrashLoopBackOff",
+			status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "app",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				}},
+			},
+			reason: "CrashLoopBackOff",
+		},
+		{
+			name: "ImagePullBackOff in init container",
+			status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				InitContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "init",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+				}},
+			},
+			reason: "ImagePullBackOff",
+		},
+		{
+			name: "ContainerCreating",
+			status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "app",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+				}},
+			},
+			reason: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, _ := podFailure(&corev1.Pod{Status: tt.status})
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestStatefulSetFailure(t *testing.T) {
+	sts := &appsv1.StatefulSet{
+		Spec:   appsv1.StatefulSetSpec{Replicas: int32Ptr(3)},
+		Status: appsv1.StatefulSetStatus{ReadyReplicas: 1},
+	}
+
+	reason, _ := statefulSetFailure(sts, false, metav1.NewTime(time.Now().Add(-time.Minute)))
+	assert.Empty(t, reason)
+
+	reason, detail := statefulSetFailure(sts, false, metav1.NewTime(time.Now().Add(-15*time.Minute)))
+	assert.Equal(t, "PodsNotReady", reason)
+	assert.Contains(t, detail, "1/3 pods ready")
+
+	reason, _ = statefulSetFailure(sts, true, metav1.NewTime(time.Now().Add(-15*time.Minute)))
+	assert.Empty(t, reason)
+}
+
+func TestReconcileDeploymentFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	slack := newFakeNotifier()
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(3),
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas:   1,
+			UpdatedReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "test-app-5d4f" has timed out progressing.`,
+			}},
+		},
+	}
+
+	for _, alertOnFail := range []bool{true, false} {
+		tracker := &ddukbgv1alpha1.ResourceTracker{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+			Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+				Target: ddukbgv1alpha1.ResourceTarget{
+					Kind:      "Deployment",
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Notify: ddukbgv1alpha1.NotifyConfig{
+					Slack:       "https://hooks.slack.com/test",
+					AlertOnFail: alertOnFail,
+				},
+			},
+		}
+
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(tracker, deploy.DeepCopy()).
+			WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+			Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &ResourceTrackerReconciler{
+			Client:    c,
+			Scheme:    scheme,
+			Recorder:  recorder,
+			Notifiers: newFakeRegistry(ChannelSlack, slack),
+		}
+
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+
+		assert.Contains(t, <-recorder.Events, "DeploymentFailed")
+
+		if alertOnFail {
+			event := <-slack.events
+			assert.Equal(t, EventFailed, event.Type)
+			assert.Equal(t, "ProgressDeadlineExceeded", event.Reason)
+			assert.Contains(t, event.Message, "Deployment default/test-app has failed")
+		} else {
+			assert.Empty(t, slack.events)
+		}
+
+		updated := &ddukbgv1alpha1.ResourceTracker{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(tracker), updated))
+		assert.Equal(t, "ProgressDeadlineExceeded", updated.Status.Failures["default/test-app"])
+
+		// 같은 실패는 다시 알리지 않음
+		_, err = r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		assert.Empty(t, slack.events)
+	}
+}
+
+func TestReconcilePodCrashLoopRecovery(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	var received []PagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event PagerDutyEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		received = append(received, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	// CrashLoopBackOff인 Pod도 phase는 Running
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "api:1.0"}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "app",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pagerduty", Namespace: "default"},
+		Data:       map[string][]byte{"routingKey": []byte("R0UT1NGKEY")},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "Pod", Name: "api", Namespace: "default"},
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				AlertOnFail: true,
+				PagerDuty: &ddukbgv1alpha1.PagerDutyConfig{
+					RoutingKeySecretRef: ddukbgv1alpha1.SecretKeyRef{Name: "pagerduty", Key: "routingKey"},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker, pod, secret).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}, &corev1.Pod{}).
+		Build()
+	notifier := NewPagerDutyNotifier(c)
+	notifier.EventsURL = server.URL
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: newFakeRegistry(ChannelPagerDuty, notifier),
+	}
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, "trigger", received[0].EventAction)
+
+	// 컨테이너가 다시 뜨면 Ready 전환 없이 실패만 해소됨
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), pod))
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	require.NoError(t, c.Status().Update(ctx, pod))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+	assert.Equal(t, "resolve", received[1].EventAction)
+	assert.Equal(t, received[0].DedupKey, received[1].DedupKey)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Empty(t, updated.Status.Failures)
+	assert.Empty(t, updated.Status.PagerDutyIncidents)
+}
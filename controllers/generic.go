@@ -0,0 +1,357 @@
+// controllers/generic.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// builtinKinds are the kinds with dedicated reconcilers, by apiVersion
+var builtinKinds = map[string]string{
+	"Deployment":  "apps/v1",
+	"StatefulSet": "apps/v1",
+	"DaemonSet":   "apps/v1",
+	"Job":         "batch/v1",
+	"CronJob":     "batch/v1",
+	"Pod":         "v1",
+	"Rollout":     "argoproj.io/v1alpha1",
+}
+
+// kstatus statuses of generically tracked resources
+const (
+	statusCurrent     = "Current"
+	statusInProgress  = "InProgress"
+	statusFailed      = "Failed"
+	statusTerminating = "Terminating"
+)
+
+// genericTarget reports whether the target is tracked through unstructured objects
+// rather than a dedicated reconciler
+func genericTarget(target ddukbgv1alpha1.ResourceTarget) bool {
+	apiVersion, builtin := builtinKinds[target.Kind]
+	return target.APIVersion != "" && (!builtin || target.APIVersion != apiVersion)
+}
+
+// targetGVK returns the GroupVersionKind of a generic target
+func targetGVK(target ddukbgv1alpha1.ResourceTarget) (schema.GroupVersionKind, error) {
+	gv, err := schema.ParseGroupVersion(target.APIVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("invalid apiVersion %q: %w", target.APIVersion, err)
+	}
+	return gv.WithKind(target.Kind), nil
+}
+
+// genericCondition is a condition of an unstructured resource
+type genericCondition struct {
+	status, reason, message string
+	observedGeneration      int64
+}
+
+// computeStatus reports the status of a resource following the kstatus conventions:
+// a resource is Current once its controller observed the latest generation and
+// no Reconciling, Stalled, or false Ready/Available condition remains. Stalled
+// resources have Failed, with the condition's reason.
+func computeStatus(u *unstructured.Unstructured) (status, reason, message string) {
+	if u.GetDeletionTimestamp() != nil {
+		return statusTerminating, "Terminating", "the resource is being deleted"
+	}
+
+	generation := u.GetGeneration()
+	if observed, found, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration"); found && observed < generation {
+		return statusInProgress, "ObservedGenerationOutdated",
+			fmt.Sprintf("the controller observed generation %d, the latest is %d", observed, generation)
+	}
+
+	conditions := genericConditions(u)
+	if c, ok := conditions["Stalled"]; ok && c.status == string(corev1.ConditionTrue) {
+		return statusFailed, orDefault(c.reason, "Stalled"), c.message
+	}
+	if c, ok := conditions["Reconciling"]; ok && c.status == string(corev1.ConditionTrue) {
+		return statusInProgress, orDefault(c.reason, "Reconciling"), c.message
+	}
+	for _, condType := range []string{"Ready", "Available"} {
+		c, ok := conditions[condType]
+		if !ok {
+			continue
+		}
+		if c.status != string(corev1.ConditionTrue) {
+			return statusInProgress, orDefault(c.reason, condType+"ConditionNotTrue"), c.message
+		}
+		// 조건이 이전 세대에 대해 기록된 경우
+		if c.observedGeneration != 0 && c.observedGeneration < generation {
+			return statusInProgress, "ObservedGenerationOutdated",
+				fmt.Sprintf("the %s condition is for generation %d, the latest is %d", condType, c.observedGeneration, generation)
+		}
+	}
+	return statusCurrent, "", ""
+}
+
+// genericConditions returns the status.conditions of a resource by type
+func genericConditions(u *unstructured.Unstructured) map[string]genericCondition {
+	items, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	conditions := make(map[string]genericCondition, len(items))
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condType, _, _ := unstructured.NestedString(fields, "type")
+		c := genericCondition{}
+		c.status, _, _ = unstructured.NestedString(fields, "status")
+		c.reason, _, _ = unstructured.NestedString(fields, "reason")
+		c.message, _, _ = unstructured.NestedString(fields, "message")
+		c.observedGeneration, _, _ = unstructured.NestedInt64(fields, "observedGeneration")
+		conditions[condType] = c
+	}
+	return conditions
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// genericEvent builds the event of an unstructured resource. Replicas are taken
+// from spec.replicas and status.readyReplicas when the resource has them, and
+// images from a pod template in spec.template.
+func genericEvent(u *unstructured.Unstructured, status string) NotificationEvent {
+	isReady := status == statusCurrent
+	ready, total := boolToInt32(isReady), int32(1)
+	if replicas, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas"); found {
+		total = int32(replicas)
+		readyReplicas, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+		ready = int32(readyReplicas)
+	}
+
+	var images []string
+	containers, _, _ := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+	for _, container := range containers {
+		if fields, ok := container.(map[string]interface{}); ok {
+			if image, _, _ := unstructured.NestedString(fields, "image"); image != "" {
+				images = append(images, image)
+			}
+		}
+	}
+
+	return NotificationEvent{
+		Kind:          u.GetKind(),
+		Namespace:     u.GetNamespace(),
+		Name:          u.GetName(),
+		Labels:        u.GetLabels(),
+		Annotations:   u.GetAnnotations(),
+		ReadyReplicas: ready,
+		TotalReplicas: total,
+		Images:        images,
+		Phase:         status,
+		Revision:      strconv.FormatInt(u.GetGeneration(), 10),
+		Timestamp:     time.Now(),
+	}
+}
+
+// ensureWatch watches resources of the kind so that their changes trigger the
+// trackers following them. Watches are registered once per kind, when the
+// first tracker of the kind is reconciled.
+func (r *ResourceTrackerReconciler) ensureWatch(ctx context.Context, gvk schema.GroupVersionKind) error {
+	// 매니저 없이 실행되는 경우(테스트)에는 주기적인 재조정에 맡김
+	if r.controller == nil {
+		return nil
+	}
+
+	r.watchesMu.Lock()
+	defer r.watchesMu.Unlock()
+	if r.watches[gvk] {
+		return nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := r.controller.Watch(source.Kind(r.cache, client.Object(obj),
+		handler.EnqueueRequestsFromMapFunc(r.findObjectsForResource))); err != nil {
+		return err
+	}
+
+	if r.watches == nil {
+		r.watches = make(map[schema.GroupVersionKind]bool)
+	}
+	r.watches[gvk] = true
+	log.FromContext(ctx).Info("Watching resources for generic trackers", "gvk", gvk.String())
+	return nil
+}
+
+// reconcileGeneric handles resources of any kind given by apiVersion and kind
+func (r *ResourceTrackerReconciler) reconcileGeneric(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
+	gvk, err := targetGVK(tracker.Spec.Target)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return r.reconcileUnstructured(ctx, tracker, gvk, r.observeGeneric)
+}
+
+// observeFunc records the state of an unstructured resource and notifies its
+// changes. It returns whether the tracker status changed and the event
+// describing the resource.
+type observeFunc func(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	u *unstructured.Unstructured) (statusChanged bool, event NotificationEvent)
+
+// reconcileUnstructured handles resources of a kind without Go types, observing
+// each of them with observe
+func (r *ResourceTrackerReconciler) reconcileUnstructured(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	gvk schema.GroupVersionKind, observe observeFunc) (ctrl.Result, error) {
+	// 설치되지 않은 CRD를 watch하면 informer가 계속 재시도하므로 먼저 확인
+	if _, err := r.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return r.kindNotServed(ctx, tracker, gvk)
+		}
+		return ctrl.Result{}, err
+	}
+	if err := r.ensureWatch(ctx, gvk); err != nil {
+		return ctrl.Result{}, err
+	}
+	// CRD가 설치되면 이전 메시지를 지움
+	statusChanged := false
+	if tracker.Status.Message == notServedMessage(gvk) {
+		tracker.Status.Message = ""
+		statusChanged = true
+	}
+
+	// 네임스페이스 전체 또는 여러 네임스페이스 모니터링인 경우
+	if !singleResource(tracker.Spec.Target) {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := r.listTargets(ctx, tracker.Spec.Target, list); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		readyResources := 0
+		present := make(map[string]bool, len(list.Items))
+		for i := range list.Items {
+			u := &list.Items[i]
+			key := fmt.Sprintf("%s/%s", u.GetNamespace(), u.GetName())
+			present[key] = true
+
+			if changed, _ := observe(ctx, tracker, u); changed {
+				statusChanged = true
+			}
+			if tracker.Status.ResourceStatus[key] {
+				readyResources++
+			}
+		}
+		if r.forgetDeletedResources(ctx, tracker, present) {
+			statusChanged = true
+		}
+
+		if statusChanged {
+			tracker.Status.CurrentState.ReadyReplicas = int32(readyResources)
+			tracker.Status.CurrentState.TotalReplicas = int32(len(list.Items))
+			if err := r.updateStatus(ctx, tracker); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+	}
+
+	// 단일 리소스 모니터링 로직
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      tracker.Spec.Target.Name,
+		Namespace: tracker.Spec.Target.Namespace,
+	}, u); err != nil {
+		// 추적하던 리소스가 삭제되었으면 삭제 알림 후 상태 정리
+		if apierrors.IsNotFound(err) && (r.forgetDeletedResources(ctx, tracker, nil) || statusChanged) {
+			return ctrl.Result{}, r.updateStatus(ctx, tracker)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	changed, event := observe(ctx, tracker, u)
+	if changed || statusChanged {
+		tracker.Status.CurrentState.ReadyReplicas = event.ReadyReplicas
+		tracker.Status.CurrentState.TotalReplicas = event.TotalReplicas
+		if err := r.updateStatus(ctx, tracker); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+}
+
+// observeGeneric is the observeFunc of generic targets, reporting readiness by
+// the kstatus conventions
+func (r *ResourceTrackerReconciler) observeGeneric(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	u *unstructured.Unstructured) (statusChanged bool, event NotificationEvent) {
+	logger := log.FromContext(ctx)
+
+	key := fmt.Sprintf("%s/%s", u.GetNamespace(), u.GetName())
+	status, reason, message := computeStatus(u)
+	isReady := status == statusCurrent
+
+	event = genericEvent(u, status)
+	change := recordResourceState(tracker, key, isReady, &event)
+	statusChanged = change.statusChanged
+	r.notifyChanges(ctx, tracker, event, change)
+
+	if tracker.Status.ResourceStatus[key] != isReady {
+		statusChanged = true
+		tracker.Status.ResourceStatus[key] = isReady
+
+		if isReady {
+			r.Recorder.Event(tracker, corev1.EventTypeNormal, u.GetKind()+"Ready",
+				fmt.Sprintf("%s %s is ready", u.GetKind(), key))
+
+			event.Type = EventReady
+		} else {
+			event.Type = EventNotReady
+			event.Reason, event.Detail = reason, message
+		}
+		if err := r.sendNotifications(ctx, tracker, event); err != nil {
+			logger.Error(err, "Failed to send notification")
+		}
+	}
+
+	// Stalled 상태만 실패로 보고
+	if status != statusFailed {
+		reason, message = "", ""
+	}
+	if r.updateFailureStatus(ctx, tracker, event, reason, message) {
+		statusChanged = true
+	}
+	return statusChanged, event
+}
+
+func notServedMessage(gvk schema.GroupVersionKind) string {
+	return fmt.Sprintf("%s is not served by the cluster; is its CRD installed?", gvk.String())
+}
+
+// kindNotServed records that the cluster does not serve the target kind, e.g.
+// because its CRD is not installed, and checks again later
+func (r *ResourceTrackerReconciler) kindNotServed(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	gvk schema.GroupVersionKind) (ctrl.Result, error) {
+	if message := notServedMessage(gvk); tracker.Status.Message != message {
+		tracker.Status.Message = message
+		if err := r.updateStatus(ctx, tracker); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
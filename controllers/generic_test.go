@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// certificate returns a cert-manager Certificate with the given status
+func certificate(name string, generation int64, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"secretName": name + "-tls"},
+		"status": status,
+	}}
+	u.SetGroupVersionKind(certificateGVK)
+	u.SetNamespace("default")
+	u.SetName(name)
+	u.SetGeneration(generation)
+	return u
+}
+
+func condition(condType, status, reason string) map[string]interface{} {
+	return map[string]interface{}{"type": condType, "status": status, "reason": reason, "message": reason + " message"}
+}
+
+func TestComputeStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status map[string]interface{}
+		want   string
+		reason string
+	}{
+		{"no status", map[string]interface{}{}, statusCurrent, ""},
+		{"ready", map[string]interface{}{
+			"observedGeneration": int64(2),
+			"conditions":         []interface{}{condition("Ready", "True", "Ready")},
+		}, statusCurrent, ""},
+		{"generation not observed", map[string]interface{}{
+			"observedGeneration": int64(1),
+			"conditions":         []interface{}{condition("Ready", "True", "Ready")},
+		}, statusInProgress, "ObservedGenerationOutdated"},
+		{"not ready", map[string]interface{}{
+			"conditions": []interface{}{condition("Ready", "False", "Issuing")},
+		}, statusInProgress, "Issuing"},
+		{"unavailable", map[string]interface{}{
+			"conditions": []interface{}{condition("Available", "Unknown", "")},
+		}, statusInProgress, "AvailableConditionNotTrue"},
+		{"reconciling", map[string]interface{}{
+			"conditions": []interface{}{condition("Reconciling", "True", "Progressing"), condition("Ready", "True", "")},
+		}, statusInProgress, "Progressing"},
+		{"stalled", map[string]interface{}{
+			"conditions": []interface{}{condition("Stalled", "True", "InvalidSpec"), condition("Ready", "False", "")},
+		}, statusFailed, "InvalidSpec"},
+		{"condition of old generation", map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True", "observedGeneration": int64(1)}},
+		}, statusInProgress, "ObservedGenerationOutdated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, reason, _ := computeStatus(certificate("web", 2, tt.status))
+			assert.Equal(t, tt.want, status)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	deleting := certificate("web", 2, map[string]interface{}{})
+	deleting.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	status, _, _ := computeStatus(deleting)
+	assert.Equal(t, statusTerminating, status)
+}
+
+func TestGenericTarget(t *testing.T) {
+	assert.False(t, genericTarget(ddukbgv1alpha1.ResourceTarget{Kind: "Deployment"}))
+	assert.False(t, genericTarget(ddukbgv1alpha1.ResourceTarget{APIVersion: "apps/v1", Kind: "Deployment"}))
+	assert.True(t, genericTarget(ddukbgv1alpha1.ResourceTarget{APIVersion: "cert-manager.io/v1", Kind: "Certificate"}))
+	assert.True(t, genericTarget(ddukbgv1alpha1.ResourceTarget{APIVersion: "example.com/v1", Kind: "Deployment"}),
+		"a CRD sharing a built-in kind's name")
+}
+
+func TestReconcileGeneric(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(ddukbgv1alpha1.GroupVersion.WithKind("ResourceTracker"), meta.RESTScopeNamespace)
+	mapper.Add(certificateGVK, meta.RESTScopeNamespace)
+
+	slack := newFakeNotifier()
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Namespace: "default"},
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: "https://hooks.slack.com/test", AlertOnFail: true},
+		},
+	}
+	web := certificate("web", 1, map[string]interface{}{
+		"conditions": []interface{}{condition("Ready", "False", "Issuing")},
+	})
+	api := certificate("api", 1, map[string]interface{}{
+		"conditions": []interface{}{condition("Ready", "True", "Ready")},
+	})
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(mapper).
+		WithObjects(tracker, web, api).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, slack.events, 1)
+	event := <-slack.events
+	assert.Equal(t, EventReady, event.Type)
+	assert.Equal(t, "Certificate", event.Kind)
+	assert.Equal(t, "api", event.Name)
+	assert.Equal(t, statusCurrent, event.Phase)
+
+	// 발급에 실패하면 Stalled로 실패 알림
+	web.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{condition("Stalled", "True", "IssuanceFailed"), condition("Ready", "False", "Failed")},
+	}
+	require.NoError(t, c.Update(ctx, web))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, slack.events, 1)
+	event = <-slack.events
+	assert.Equal(t, EventFailed, event.Type)
+	assert.Equal(t, "IssuanceFailed", event.Reason)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.True(t, updated.Status.ResourceStatus["default/api"])
+	assert.False(t, updated.Status.ResourceStatus["default/web"])
+	assert.Equal(t, int32(1), updated.Status.CurrentState.ReadyReplicas)
+	assert.Equal(t, int32(2), updated.Status.CurrentState.TotalReplicas)
+}
+
+func TestReconcileGenericKindNotServed(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{APIVersion: "kafka.strimzi.io/v1beta2", Kind: "KafkaTopic", Namespace: "default"},
+		},
+	}
+	// KafkaTopic CRD가 설치되지 않은 클러스터
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(ddukbgv1alpha1.GroupVersion.WithKind("ResourceTracker"), meta.RESTScopeNamespace)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(mapper).
+		WithObjects(tracker).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+	r := &ResourceTrackerReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+	result, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, updated))
+	assert.Contains(t, updated.Status.Message, "is not served by the cluster")
+}
+
+func TestFindObjectsForResource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	trackers := []client.Object{
+		&ddukbgv1alpha1.ResourceTracker{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployments", Namespace: "default"},
+			Spec:       ddukbgv1alpha1.ResourceTrackerSpec{Target: ddukbgv1alpha1.ResourceTarget{Kind: "Deployment", Namespace: "default"}},
+		},
+		&ddukbgv1alpha1.ResourceTracker{
+			ObjectMeta: metav1.ObjectMeta{Name: "certificates", Namespace: "default"},
+			Spec: ddukbgv1alpha1.ResourceTrackerSpec{Target: ddukbgv1alpha1.ResourceTarget{
+				APIVersion: "cert-manager.io/v1", Kind: "Certificate", Namespace: "default"}},
+		},
+	}
+	r := &ResourceTrackerReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(trackers...).Build(),
+		Scheme: scheme,
+	}
+
+	// informer에서 온 typed 객체는 TypeMeta가 비어 있음
+	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+	requests := r.findObjectsForResource(context.Background(), deploy)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "deployments", requests[0].Name)
+
+	requests = r.findObjectsForResource(context.Background(), certificate("web", 1, nil))
+	require.Len(t, requests, 1)
+	assert.Equal(t, "certificates", requests[0].Name)
+	// 셀렉터가 있는 트래커는 레이블이 일치하는 리소스만 매핑
+	payments := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{Target: ddukbgv1alpha1.ResourceTarget{
+			Kind: "Deployment", Namespace: "default",
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}}},
+	}
+	require.NoError(t, r.Create(context.Background(), payments))
+
+	requests = r.findObjectsForResource(context.Background(), deploy)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "deployments", requests[0].Name)
+
+	deploy.Labels = map[string]string{"team": "payments"}
+	requests = r.findObjectsForResource(context.Background(), deploy)
+	assert.Len(t, requests, 2)
+	// 여러 네임스페이스를 추적하는 트래커
+	environments := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "environments", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{Target: ddukbgv1alpha1.ResourceTarget{
+			Kind: "Deployment", Name: "api", Namespaces: []string{"pay-dev", "pay-stg"}}},
+	}
+	require.NoError(t, r.Create(context.Background(), environments))
+
+	requests = r.findObjectsForResource(context.Background(),
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "pay-stg"}})
+	require.Len(t, requests, 1)
+	assert.Equal(t, "environments", requests[0].Name)
+	assert.Empty(t, r.findObjectsForResource(context.Background(),
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "pay-stg"}}))
+}
@@ -0,0 +1,38 @@
+// controllers/googlechat.go
+
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// GoogleChatMessage is the payload of a Google Chat space webhook
+type GoogleChatMessage struct {
+	Text string `json:"text"`
+}
+
+// GoogleChatNotifier posts notifications to a Google Chat space webhook
+type GoogleChatNotifier struct {
+	// Client reads the Secret holding the webhook URL
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *GoogleChatNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.GoogleChat != nil
+}
+
+// Notify implements Notifier
+func (n *GoogleChatNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	webhookURL, err := secretValue(ctx, n.Client, tracker.Namespace, &tracker.Spec.Notify.GoogleChat.URLSecretRef)
+	if err != nil {
+		return err
+	}
+
+	// Google Chat도 *굵게* 와 같은 Slack 스타일 서식을 지원
+	return postJSON(ctx, ChannelGoogleChat, webhookURL, GoogleChatMessage{Text: event.Message})
+}
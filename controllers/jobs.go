@@ -0,0 +1,509 @@
+// controllers/jobs.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// cronJobFailureThreshold is the number of consecutive failed Jobs after which
+// a CronJob is reported as failed
+const cronJobFailureThreshold = 3
+
+// missedScheduleGrace is how late a CronJob may start a Job before the
+// schedule is reported as missed
+const missedScheduleGrace = 2 * time.Minute
+
+// cronDescriptors are the schedule macros accepted by CronJobs
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// JobDetails describes the progress of a Job
+type JobDetails struct {
+	Completions  int32 `json:"completions"`
+	Parallelism  int32 `json:"parallelism"`
+	Succeeded    int32 `json:"succeeded"`
+	Failed       int32 `json:"failed"`
+	Active       int32 `json:"active"`
+	BackoffLimit int32 `json:"backoffLimit"`
+	// CronJob is the name of the CronJob that created the Job, if any
+	CronJob string `json:"cronJob,omitempty"`
+}
+
+// reconcileJob handles Job type resources
+func (r *ResourceTrackerReconciler) reconcileJob(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
+	// 네임스페이스 전체 또는 여러 네임스페이스 모니터링인 경우
+	if !singleResource(tracker.Spec.Target) {
+		jobList := &batchv1.JobList{}
+		if err := r.listTargets(ctx, tracker.Spec.Target, jobList); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		statusChanged := false
+		completedJobs := 0
+		present := make(map[string]bool, len(jobList.Items))
+		for i := range jobList.Items {
+			job := &jobList.Items[i]
+			key := fmt.Sprintf("%s/%s", job.Namespace, job.Name)
+			present[key] = true
+
+			if changed, _ := r.observeJob(ctx, tracker, job, cronJobOwner(job)); changed {
+				statusChanged = true
+			}
+			if tracker.Status.ResourceStatus[key] {
+				completedJobs++
+			}
+		}
+		if r.forgetFinishedJobs(ctx, tracker, present) {
+			statusChanged = true
+		}
+		if r.forgetDeletedResources(ctx, tracker, present) {
+			statusChanged = true
+		}
+
+		if statusChanged {
+			tracker.Status.CurrentState.ReadyReplicas = int32(completedJobs)
+			tracker.Status.CurrentState.TotalReplicas = int32(len(jobList.Items))
+			if err := r.updateStatus(ctx, tracker); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+	}
+
+	// 단일 Job 모니터링 로직
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      tracker.Spec.Target.Name,
+		Namespace: tracker.Spec.Target.Namespace,
+	}, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			// 끝난 Job이 정리된 것은 알리지 않고, 실행 중에 삭제된 Job만 삭제 알림
+			changed := r.forgetFinishedJobs(ctx, tracker, nil)
+			if r.forgetDeletedResources(ctx, tracker, nil) {
+				changed = true
+			}
+			if changed {
+				return ctrl.Result{}, r.updateStatus(ctx, tracker)
+			}
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	statusChanged, outcome := r.observeJob(ctx, tracker, job, cronJobOwner(job))
+	if statusChanged {
+		event := jobEvent(job, "")
+		tracker.Status.CurrentState.ReadyReplicas = event.ReadyReplicas
+		tracker.Status.CurrentState.TotalReplicas = event.TotalReplicas
+		switch outcome {
+		case EventCompleted:
+			tracker.Status.Message = "Job completed successfully"
+		case EventFailed:
+			tracker.Status.Message = fmt.Sprintf("Job failed: %s", tracker.Status.Failures[fmt.Sprintf("%s/%s", job.Namespace, job.Name)])
+		}
+		if err := r.updateStatus(ctx, tracker); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+}
+
+// reconcileCronJob handles CronJob type resources. The Jobs a CronJob spawns are
+// reported like tracked Jobs, and the CronJob itself fails when it misses a
+// schedule or when cronJobFailureThreshold Jobs in a row have failed.
+func (r *ResourceTrackerReconciler) reconcileCronJob(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
+	var cronJobs []batchv1.CronJob
+	if !singleResource(tracker.Spec.Target) {
+		cronJobList := &batchv1.CronJobList{}
+		if err := r.listTargets(ctx, tracker.Spec.Target, cronJobList); err != nil {
+			return ctrl.Result{}, err
+		}
+		cronJobs = cronJobList.Items
+	} else {
+		cronJob := &batchv1.CronJob{}
+		if err := r.Get(ctx, types.NamespacedName{
+			Name:      tracker.Spec.Target.Name,
+			Namespace: tracker.Spec.Target.Namespace,
+		}, cronJob); err != nil {
+			if apierrors.IsNotFound(err) {
+				changed := r.forgetFinishedJobs(ctx, tracker, nil)
+				if r.forgetDeletedResources(ctx, tracker, nil) {
+					changed = true
+				}
+				if changed {
+					return ctrl.Result{}, r.updateStatus(ctx, tracker)
+				}
+			}
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		cronJobs = []batchv1.CronJob{*cronJob}
+	}
+
+	jobList := &batchv1.JobList{}
+	if err := r.listInNamespaces(ctx, tracker.Spec.Target, jobList); err != nil {
+		return ctrl.Result{}, err
+	}
+	// 연속 실패 횟수가 끝난 순서대로 계산되도록 오래된 Job부터 처리
+	jobs := jobList.Items
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+	})
+
+	if tracker.Status.ConsecutiveFailures == nil {
+		tracker.Status.ConsecutiveFailures = make(map[string]int32)
+	}
+
+	statusChanged := false
+	healthyCronJobs := 0
+	present := make(map[string]bool, len(cronJobs))
+	now := time.Now()
+	for i := range cronJobs {
+		cronJob := &cronJobs[i]
+		key := fmt.Sprintf("%s/%s", cronJob.Namespace, cronJob.Name)
+		present[key] = true
+		if _, seen := tracker.Status.ConsecutiveFailures[key]; !seen {
+			tracker.Status.ConsecutiveFailures[key] = 0
+			statusChanged = true
+		}
+
+		for j := range jobs {
+			job := &jobs[j]
+			if !spawnedBy(job, cronJob) {
+				continue
+			}
+			present[fmt.Sprintf("%s/%s", job.Namespace, job.Name)] = true
+
+			changed, outcome := r.observeJob(ctx, tracker, job, cronJob.Name)
+			switch outcome {
+			case EventCompleted:
+				tracker.Status.ConsecutiveFailures[key] = 0
+			case EventFailed:
+				tracker.Status.ConsecutiveFailures[key]++
+			}
+			if changed || outcome != "" {
+				statusChanged = true
+			}
+		}
+
+		reason, detail := cronJobFailure(cronJob, tracker.Status.ConsecutiveFailures[key], now)
+		isHealthy := reason == ""
+		if isHealthy {
+			healthyCronJobs++
+		}
+
+		event := cronJobEvent(cronJob)
+		change := recordResourceState(tracker, key, isHealthy, &event)
+		if change.statusChanged {
+			statusChanged = true
+		}
+		r.notifyChanges(ctx, tracker, event, change)
+
+		if tracker.Status.ResourceStatus[key] != isHealthy {
+			statusChanged = true
+			tracker.Status.ResourceStatus[key] = isHealthy
+		}
+		if r.updateFailureStatus(ctx, tracker, event, reason, detail) {
+			statusChanged = true
+		}
+	}
+	if r.forgetFinishedJobs(ctx, tracker, present) {
+		statusChanged = true
+	}
+	if r.forgetDeletedResources(ctx, tracker, present) {
+		statusChanged = true
+	}
+
+	if statusChanged {
+		tracker.Status.CurrentState.ReadyReplicas = int32(healthyCronJobs)
+		tracker.Status.CurrentState.TotalReplicas = int32(len(cronJobs))
+		if err := r.updateStatus(ctx, tracker); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+}
+
+// observeJob records the state of a Job and notifies its completion or failure.
+// Jobs that finished before the tracker was created are recorded without
+// notifications. It returns whether the status changed, and EventCompleted or
+// EventFailed when the Job finished since it was last observed.
+func (r *ResourceTrackerReconciler) observeJob(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	job *batchv1.Job, cronJob string) (statusChanged bool, outcome string) {
+	logger := log.FromContext(ctx)
+
+	key := fmt.Sprintf("%s/%s", job.Namespace, job.Name)
+	finished := jobFinished(job)
+	complete := finished != nil && finished.Type == batchv1.JobComplete
+	// 트래커가 만들어지기 전에 끝난 Job은 기록만 함
+	stale := finished != nil && finished.LastTransitionTime.Before(&tracker.CreationTimestamp)
+
+	event := jobEvent(job, cronJob)
+	runtime := event.Duration
+	// Job의 템플릿과 완료 수는 바뀌지 않으므로 이미지, 스케일 변경은 알리지 않음
+	statusChanged = recordResourceState(tracker, key, complete, &event).statusChanged
+	event.Duration = runtime
+
+	if tracker.Status.ResourceStatus[key] != complete {
+		statusChanged = true
+		tracker.Status.ResourceStatus[key] = complete
+
+		if complete && !stale {
+			outcome = EventCompleted
+			r.Recorder.Event(tracker, corev1.EventTypeNormal, "JobCompleted",
+				fmt.Sprintf("Job %s completed in %s", key, runtime))
+
+			event.Type = EventCompleted
+			if err := r.sendNotifications(ctx, tracker, event); err != nil {
+				logger.Error(err, "Failed to send notification")
+			}
+		}
+	}
+
+	reason, detail := jobFailure(job)
+	if stale {
+		if reason != "" && tracker.Status.Failures[key] != reason {
+			if tracker.Status.Failures == nil {
+				tracker.Status.Failures = make(map[string]string)
+			}
+			tracker.Status.Failures[key] = reason
+			statusChanged = true
+		}
+		return statusChanged, outcome
+	}
+	if reason != "" && tracker.Status.Failures[key] != reason {
+		outcome = EventFailed
+	}
+	if r.updateFailureStatus(ctx, tracker, event, reason, detail) {
+		statusChanged = true
+	}
+	return statusChanged, outcome
+}
+
+// jobFinished returns the Complete or Failed condition of a finished Job
+func jobFinished(job *batchv1.Job) *batchv1.JobCondition {
+	for i, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) && cond.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// jobFailure reports why a Job failed, or "" if it has not
+func jobFailure(job *batchv1.Job) (reason, detail string) {
+	finished := jobFinished(job)
+	if finished == nil || finished.Type != batchv1.JobFailed {
+		return "", ""
+	}
+	reason, detail = finished.Reason, finished.Message
+	if reason == "" {
+		reason = string(batchv1.JobFailed)
+	}
+	if reason == "BackoffLimitExceeded" {
+		detail = fmt.Sprintf("%d pods failed, backoffLimit of %d exhausted", job.Status.Failed, backoffLimit(job))
+	}
+	return reason, detail
+}
+
+func jobEvent(job *batchv1.Job, cronJob string) NotificationEvent {
+	// 완료 수를 지정하지 않은 Job은 Pod 하나가 성공하면 완료
+	completions, parallelism := int32(1), int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	if job.Spec.Parallelism != nil {
+		parallelism = *job.Spec.Parallelism
+	}
+
+	event := NotificationEvent{
+		Kind:          "Job",
+		Namespace:     job.Namespace,
+		Name:          job.Name,
+		Labels:        job.Labels,
+		Annotations:   job.Annotations,
+		ReadyReplicas: job.Status.Succeeded,
+		TotalReplicas: completions,
+		Images:        containerImages(job.Spec.Template.Spec),
+		Phase:         "Running",
+		Job: &JobDetails{
+			Completions:  completions,
+			Parallelism:  parallelism,
+			Succeeded:    job.Status.Succeeded,
+			Failed:       job.Status.Failed,
+			Active:       job.Status.Active,
+			BackoffLimit: backoffLimit(job),
+			CronJob:      cronJob,
+		},
+		Timestamp: time.Now(),
+	}
+	if finished := jobFinished(job); finished != nil {
+		event.Phase = string(finished.Type)
+		if job.Status.StartTime != nil {
+			end := finished.LastTransitionTime.Time
+			if job.Status.CompletionTime != nil {
+				end = job.Status.CompletionTime.Time
+			}
+			event.Duration = end.Sub(job.Status.StartTime.Time).Round(time.Second)
+		}
+	}
+	return event
+}
+
+func backoffLimit(job *batchv1.Job) int32 {
+	if job.Spec.BackoffLimit == nil {
+		return 6
+	}
+	return *job.Spec.BackoffLimit
+}
+
+func cronJobEvent(cronJob *batchv1.CronJob) NotificationEvent {
+	phase := "Idle"
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		phase = "Suspended"
+	} else if len(cronJob.Status.Active) > 0 {
+		phase = "Active"
+	}
+	return NotificationEvent{
+		Kind:        "CronJob",
+		Namespace:   cronJob.Namespace,
+		Name:        cronJob.Name,
+		Labels:      cronJob.Labels,
+		Annotations: cronJob.Annotations,
+		Images:      containerImages(cronJob.Spec.JobTemplate.Spec.Template.Spec),
+		Phase:       phase,
+		Timestamp:   time.Now(),
+	}
+}
+
+// cronJobOwner returns the name of the CronJob that created the Job, if any
+func cronJobOwner(job *batchv1.Job) string {
+	if owner := metav1.GetControllerOf(job); owner != nil && owner.Kind == "CronJob" {
+		return owner.Name
+	}
+	return ""
+}
+
+// spawnedBy reports whether the CronJob created the Job, by its controller
+// reference or its active Jobs
+func spawnedBy(job *batchv1.Job, cronJob *batchv1.CronJob) bool {
+	if job.Namespace == cronJob.Namespace && cronJobOwner(job) == cronJob.Name {
+		return true
+	}
+	for _, ref := range cronJob.Status.Active {
+		if ref.Namespace == job.Namespace && ref.Name == job.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// cronJobFailure reports a CronJob whose last Jobs failed in a row or that
+// missed a schedule, or "" if it is healthy
+func cronJobFailure(cronJob *batchv1.CronJob, consecutiveFailures int32, now time.Time) (reason, detail string) {
+	if consecutiveFailures >= cronJobFailureThreshold {
+		return "ConsecutiveFailures", fmt.Sprintf("the last %d Jobs failed", consecutiveFailures)
+	}
+	if missed, ok := missedSchedule(cronJob, now); ok {
+		return "MissedSchedule", fmt.Sprintf("no Job was started for the schedule %q at %s",
+			cronJob.Spec.Schedule, missed.Format(time.RFC3339))
+	}
+	return "", ""
+}
+
+// missedSchedule returns the latest time the CronJob should have started a Job,
+// more than missedScheduleGrace (or startingDeadlineSeconds) ago, without doing so.
+// Schedules the cron parser does not support are never reported.
+func missedSchedule(cronJob *batchv1.CronJob, now time.Time) (time.Time, bool) {
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		return time.Time{}, false
+	}
+
+	// CronJob은 timeZone이 없으면 kube-controller-manager의 시간대(보통 UTC)를 사용
+	loc := time.UTC
+	zone := ""
+	if cronJob.Spec.TimeZone != nil {
+		zone = *cronJob.Spec.TimeZone
+	}
+	spec := strings.TrimSpace(cronJob.Spec.Schedule)
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		prefix, rest, _ := strings.Cut(spec, " ")
+		_, zone, _ = strings.Cut(prefix, "=")
+		spec = strings.TrimSpace(rest)
+	}
+	if zone != "" {
+		var err error
+		if loc, err = time.LoadLocation(zone); err != nil {
+			return time.Time{}, false
+		}
+	}
+	if expr, ok := cronDescriptors[spec]; ok {
+		spec = expr
+	}
+	schedule, err := parseCron(spec)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	after := cronJob.CreationTimestamp.Time
+	if last := cronJob.Status.LastScheduleTime; last != nil && last.After(after) {
+		after = last.Time
+	}
+	grace := missedScheduleGrace
+	if deadline := cronJob.Spec.StartingDeadlineSeconds; deadline != nil && time.Duration(*deadline)*time.Second > grace {
+		grace = time.Duration(*deadline) * time.Second
+	}
+	return schedule.previous(now.Add(-grace).In(loc), after)
+}
+
+// forgetFinishedJobs removes the state of finished Jobs that no longer exist, e.g.
+// after ttlSecondsAfterFinished or the CronJob history limits, without notifying
+// except for resolving the incidents of failed Jobs. CronJobs, which have an entry
+// in ConsecutiveFailures, are left to forgetDeletedResources.
+// It returns true if the status was modified.
+func (r *ResourceTrackerReconciler) forgetFinishedJobs(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	present map[string]bool) bool {
+	finished := make(map[string]bool)
+	for key, complete := range tracker.Status.ResourceStatus {
+		if complete {
+			finished[key] = true
+		}
+	}
+	for key := range tracker.Status.Failures {
+		finished[key] = true
+	}
+
+	changed := false
+	for key := range finished {
+		if _, cronJob := tracker.Status.ConsecutiveFailures[key]; present[key] || cronJob {
+			continue
+		}
+		changed = true
+		r.forgetUntracked(ctx, tracker, key, "Job")
+	}
+	return changed
+}
@@ -0,0 +1,400 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// finishedJob returns a Job that started an hour ago and finished with the condition
+func finishedJob(name string, condType batchv1.JobConditionType, reason string) *batchv1.Job {
+	start := metav1.NewTime(time.Now().Add(-time.Hour))
+	end := metav1.NewTime(start.Add(95 * time.Second))
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: start},
+		Spec: batchv1.JobSpec{
+			Completions:  int32Ptr(2),
+			Parallelism:  int32Ptr(2),
+			BackoffLimit: int32Ptr(3),
+		},
+		Status: batchv1.JobStatus{StartTime: &start},
+	}
+	if condType == "" {
+		job.Status.Active = 2
+		return job
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type: condType, Status: corev1.ConditionTrue, Reason: reason, LastTransitionTime: end,
+	}}
+	if condType == batchv1.JobComplete {
+		job.Status.Succeeded = 2
+		job.Status.CompletionTime = &end
+	} else {
+		job.Status.Failed = 4
+	}
+	return job
+}
+
+func newJobScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	return scheme
+}
+
+func TestJobFailure(t *testing.T) {
+	reason, _ := jobFailure(finishedJob("backup", "", ""))
+	assert.Empty(t, reason, "running")
+
+	reason, _ = jobFailure(finishedJob("backup", batchv1.JobComplete, ""))
+	assert.Empty(t, reason, "complete")
+
+	reason, detail := jobFailure(finishedJob("backup", batchv1.JobFailed, "BackoffLimitExceeded"))
+	assert.Equal(t, "BackoffLimitExceeded", reason)
+	assert.Equal(t, "4 pods failed, backoffLimit of 3 exhausted", detail)
+
+	reason, _ = jobFailure(finishedJob("backup", batchv1.JobFailed, "DeadlineExceeded"))
+	assert.Equal(t, "DeadlineExceeded", reason)
+}
+
+func TestReconcileJob(t *testing.T) {
+	scheme := newJobScheme()
+	slack := newFakeNotifier()
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-tracker",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "Job", Namespace: "default"},
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: "https://hooks.slack.com/test", AlertOnFail: true},
+		},
+	}
+	migrate := finishedJob("migrate", "", "")
+	backup := finishedJob("backup", "", "")
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker, migrate, backup).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}, &batchv1.Job{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, slack.events, "running Jobs are not reported")
+
+	// 하나는 완료, 하나는 실패
+	migrate.Status = finishedJob("migrate", batchv1.JobComplete, "").Status
+	require.NoError(t, c.Status().Update(ctx, migrate))
+	backup.Status = finishedJob("backup", batchv1.JobFailed, "BackoffLimitExceeded").Status
+	require.NoError(t, c.Status().Update(ctx, backup))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, slack.events, 2)
+	events := map[string]NotificationEvent{}
+	for i := 0; i < 2; i++ {
+		event := <-slack.events
+		events[event.Name] = event
+	}
+	completed := events["migrate"]
+	assert.Equal(t, EventCompleted, completed.Type)
+	assert.Equal(t, 95*time.Second, completed.Duration)
+	require.NotNil(t, completed.Job)
+	assert.Equal(t, int32(2), completed.Job.Succeeded)
+	assert.Contains(t, completed.Message, "> Completions: 2/2 (parallelism 2)")
+
+	failed := events["backup"]
+	assert.Equal(t, EventFailed, failed.Type)
+	assert.Equal(t, "BackoffLimitExceeded", failed.Reason)
+	assert.Equal(t, "4 pods failed, backoffLimit of 3 exhausted", failed.Detail)
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, slack.events, "finished Jobs are reported once")
+
+	// ttlSecondsAfterFinished로 정리된 Job은 삭제 알림 없이 잊음
+	require.NoError(t, c.Delete(ctx, migrate))
+	require.NoError(t, c.Delete(ctx, backup))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, slack.events)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Empty(t, updated.Status.ResourceStatus)
+	assert.Empty(t, updated.Status.Failures)
+}
+
+func TestReconcileJobFinishedBeforeTracker(t *testing.T) {
+	scheme := newJobScheme()
+	slack := newFakeNotifier()
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default", CreationTimestamp: metav1.Now()},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "Job", Name: "backup", Namespace: "default"},
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: "https://hooks.slack.com/test", AlertOnFail: true},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker, finishedJob("backup", batchv1.JobFailed, "DeadlineExceeded")).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, slack.events)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, updated))
+	assert.Equal(t, "DeadlineExceeded", updated.Status.Failures["default/backup"], "the failure is still recorded")
+}
+
+func TestMissedSchedule(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cronJob := func(schedule string, lastSchedule time.Time) *batchv1.CronJob {
+		cj := &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-30 * 24 * time.Hour))},
+			Spec:       batchv1.CronJobSpec{Schedule: schedule},
+		}
+		if !lastSchedule.IsZero() {
+			last := metav1.NewTime(lastSchedule)
+			cj.Status.LastScheduleTime = &last
+		}
+		return cj
+	}
+
+	_, ok := missedSchedule(cronJob("0 * * * *", now.Add(-time.Hour)), now)
+	assert.False(t, ok, "started on time")
+
+	missed, ok := missedSchedule(cronJob("0 * * * *", now.Add(-2*time.Hour)), now)
+	require.True(t, ok)
+	assert.Equal(t, now.Add(-time.Hour), missed)
+
+	_, ok = missedSchedule(cronJob("0 * * * *", now.Add(-time.Hour)), now.Add(time.Minute))
+	assert.False(t, ok, "the 12:00 run is within the grace period")
+
+	_, ok = missedSchedule(cronJob("@daily", now.Add(-12*time.Hour)), now)
+	assert.False(t, ok)
+
+	// 서울 03:00은 UTC 18:00
+	_, ok = missedSchedule(cronJob("CRON_TZ=Asia/Seoul 0 3 * * *", now.Add(-18*time.Hour)), now)
+	assert.False(t, ok)
+	_, ok = missedSchedule(cronJob("0 3 * * *", now.Add(-18*time.Hour)), now)
+	assert.True(t, ok, "03:00 UTC was missed")
+
+	suspended := cronJob("0 * * * *", now.Add(-5*time.Hour))
+	suspended.Spec.Suspend = new(bool)
+	*suspended.Spec.Suspend = true
+	_, ok = missedSchedule(suspended, now)
+	assert.False(t, ok)
+
+	deadline := cronJob("0 * * * *", now.Add(-time.Hour))
+	deadline.Spec.StartingDeadlineSeconds = new(int64)
+	*deadline.Spec.StartingDeadlineSeconds = 600
+	_, ok = missedSchedule(deadline, now.Add(5*time.Minute))
+	assert.False(t, ok, "the Job may still start before the deadline")
+}
+
+func TestReconcileCronJob(t *testing.T) {
+	scheme := newJobScheme()
+	slack := newFakeNotifier()
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-tracker",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "CronJob", Name: "report", Namespace: "default"},
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: "https://hooks.slack.com/test", AlertOnFail: true},
+		},
+	}
+	lastSchedule := metav1.NewTime(time.Now().Truncate(time.Minute))
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "default", UID: "cj-uid",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-24 * time.Hour))},
+		Spec:   batchv1.CronJobSpec{Schedule: "* * * * *"},
+		Status: batchv1.CronJobStatus{LastScheduleTime: &lastSchedule},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker, cronJob).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}, &batchv1.CronJob{}, &batchv1.Job{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(20),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+	spawn := func(name string, condType batchv1.JobConditionType, offset time.Duration) {
+		job := finishedJob(name, condType, "BackoffLimitExceeded")
+		job.CreationTimestamp = metav1.NewTime(job.CreationTimestamp.Add(offset))
+		job.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "batch/v1", Kind: "CronJob", Name: "report", UID: "cj-uid", Controller: new(bool),
+		}}
+		*job.OwnerReferences[0].Controller = true
+		require.NoError(t, c.Create(ctx, job))
+		status := job.Status
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(job), job))
+		job.Status = status
+		require.NoError(t, c.Status().Update(ctx, job))
+	}
+
+	// 성공 후 두 번 실패하면 Job 실패 알림만 발송
+	spawn("report-1", batchv1.JobComplete, 0)
+	spawn("report-2", batchv1.JobFailed, time.Minute)
+	spawn("report-3", batchv1.JobFailed, 2*time.Minute)
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, slack.events, 3)
+	first := <-slack.events
+	assert.Equal(t, EventCompleted, first.Type)
+	assert.Equal(t, "report", first.Job.CronJob)
+	assert.Equal(t, EventFailed, (<-slack.events).Type)
+	assert.Equal(t, EventFailed, (<-slack.events).Type)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Equal(t, int32(2), updated.Status.ConsecutiveFailures["default/report"])
+	assert.True(t, updated.Status.ResourceStatus["default/report"])
+
+	// 세 번째 연속 실패에서 CronJob 실패 알림
+	spawn("report-4", batchv1.JobFailed, 3*time.Minute)
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, slack.events, 2)
+	assert.Equal(t, "Job", (<-slack.events).Kind)
+	cronFailure := <-slack.events
+	assert.Equal(t, "CronJob", cronFailure.Kind)
+	assert.Equal(t, "ConsecutiveFailures", cronFailure.Reason)
+	assert.Equal(t, "the last 3 Jobs failed", cronFailure.Detail)
+
+	// 오래된 Job이 정리되어도 삭제 알림 없음
+	require.NoError(t, c.Delete(ctx, &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "report-1", Namespace: "default"}}))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, slack.events)
+}
+
+func TestReconcileCronJobMissedSchedule(t *testing.T) {
+	scheme := newJobScheme()
+	slack := newFakeNotifier()
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "CronJob", Namespace: "default"},
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: "https://hooks.slack.com/test", AlertOnFail: true},
+		},
+	}
+	lastSchedule := metav1.NewTime(time.Now().Add(-3 * time.Hour))
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "sync", Namespace: "default",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-24 * time.Hour))},
+		Spec:   batchv1.CronJobSpec{Schedule: "@hourly"},
+		Status: batchv1.CronJobStatus{LastScheduleTime: &lastSchedule},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker, cronJob).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}, &batchv1.CronJob{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, slack.events, 1)
+	event := <-slack.events
+	assert.Equal(t, EventFailed, event.Type)
+	assert.Equal(t, "MissedSchedule", event.Reason)
+	assert.Contains(t, event.Detail, `no Job was started for the schedule "@hourly"`)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, updated))
+	assert.False(t, updated.Status.ResourceStatus["default/sync"])
+}
+
+func TestFindObjectsForCronJobJob(t *testing.T) {
+	scheme := newJobScheme()
+	cronJobTracker := func(name string, selector *metav1.LabelSelector) *ddukbgv1alpha1.ResourceTracker {
+		return &ddukbgv1alpha1.ResourceTracker{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: ddukbgv1alpha1.ResourceTrackerSpec{Target: ddukbgv1alpha1.ResourceTarget{
+				Kind: "CronJob", Namespace: "default", Selector: selector}},
+		}
+	}
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "default", UID: "cj-uid",
+		Labels: map[string]string{"team": "data"}}}
+	r := &ResourceTrackerReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			cronJobTracker("all", nil),
+			cronJobTracker("data", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "data"}}),
+			cronJobTracker("payments", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}),
+			cronJob,
+		).Build(),
+		Scheme: scheme,
+	}
+
+	// Job에는 CronJob의 레이블이 없으므로 셀렉터는 CronJob의 레이블로 확인
+	job := finishedJob("report-28000000", batchv1.JobComplete, "")
+	job.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "batch/v1", Kind: "CronJob", Name: "report", UID: "cj-uid", Controller: new(bool),
+	}}
+	*job.OwnerReferences[0].Controller = true
+	var names []string
+	for _, req := range r.findObjectsForResource(context.Background(), job) {
+		names = append(names, req.Name)
+	}
+	assert.ElementsMatch(t, []string{"all", "data"}, names)
+
+	// CronJob이 삭제된 Job은 셀렉터가 없는 트래커로만 전달
+	require.NoError(t, r.Delete(context.Background(), cronJob))
+	requests := r.findObjectsForResource(context.Background(), job)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "all", requests[0].Name)
+}
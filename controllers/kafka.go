@@ -0,0 +1,93 @@
+// controllers/kafka.go
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// kafkaJSONContentType is the embedded JSON format of the REST Proxy v2 API
+const kafkaJSONContentType = "application/vnd.kafka.json.v2+json"
+
+// kafkaProduceRequest is the body of POST /topics/<topic>
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string      `json:"key"`
+	Value EventRecord `json:"value"`
+}
+
+// kafkaProduceResponse reports the offset or the error of each record
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// KafkaNotifier produces every transition to a Kafka topic through a REST proxy
+type KafkaNotifier struct {
+	endpoint string
+}
+
+// NewKafkaNotifier creates a notifier producing to the configured topic
+func NewKafkaNotifier(cfg *KafkaConfig) *KafkaNotifier {
+	return &KafkaNotifier{
+		endpoint: strings.TrimSuffix(cfg.RESTProxyURL, "/") + "/topics/" + url.PathEscape(cfg.Topic),
+	}
+}
+
+// Enabled implements Notifier; every tracker publishes to the bus
+func (n *KafkaNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return true
+}
+
+// Notify implements Notifier
+func (n *KafkaNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	// 같은 리소스의 레코드가 같은 파티션에 순서대로 쌓이도록 namespace/name을 키로 사용
+	body, err := json.Marshal(kafkaProduceRequest{
+		Records: []kafkaRecord{{Key: recordKey(tracker, event), Value: eventRecord(tracker, event)}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s message: %v", ChannelKafka, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %v", ChannelKafka, err)
+	}
+	req.Header.Set("Content-Type", kafkaJSONContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json, application/json")
+
+	resp, err := notificationHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", ChannelKafka, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newDeliveryError(ChannelKafka, resp)
+	}
+
+	// 요청이 성공해도 레코드별로 실패할 수 있음
+	var produced kafkaProduceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&produced); err != nil {
+		return fmt.Errorf("failed to decode %s response: %v", ChannelKafka, err)
+	}
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return fmt.Errorf("failed to produce %s record: %s", ChannelKafka, offset.Error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// controllers/mattermost.go
+
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// MattermostMessage is the payload of a Mattermost incoming webhook
+type MattermostMessage struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// MattermostNotifier posts notifications to a Mattermost incoming webhook
+type MattermostNotifier struct {
+	// Client reads the Secret holding the webhook URL
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *MattermostNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.Mattermost != nil
+}
+
+// Notify implements Notifier
+func (n *MattermostNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	cfg := tracker.Spec.Notify.Mattermost
+	webhookURL, err := secretValue(ctx, n.Client, tracker.Namespace, &cfg.URLSecretRef)
+	if err != nil {
+		return err
+	}
+
+	// Mattermost는 Slack과 같은 마크다운을 사용하므로 메시지를 그대로 전송
+	msg := MattermostMessage{
+		Text:     event.Message,
+		Channel:  cfg.Channel,
+		Username: cfg.Username,
+	}
+	return postJSON(ctx, ChannelMattermost, webhookURL, msg)
+}
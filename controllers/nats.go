@@ -0,0 +1,91 @@
+// controllers/nats.go
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// natsPublishTimeout bounds waiting for the server, like notificationHTTPClient
+const natsPublishTimeout = 10 * time.Second
+
+// NATSNotifier publishes every transition to a NATS subject or JetStream stream.
+// Publishing to a core NATS subject is at most once, see NATSConfig.JetStream.
+type NATSNotifier struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	subject string
+}
+
+// NewNATSNotifier connects to the configured NATS server. The connection keeps
+// reconnecting in the background; events published while it is down are retried.
+func NewNATSNotifier(cfg *NATSConfig) (*NATSNotifier, error) {
+	opts := []nats.Option{
+		nats.Name("k8s-deploy-watcher"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	}
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(cfg.CredentialsFile))
+	}
+	conn, err := nats.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	n := &NATSNotifier{conn: conn, subject: cfg.Subject}
+	if cfg.JetStream {
+		if n.js, err = jetstream.New(conn); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+		}
+	}
+	return n, nil
+}
+
+// Close drains the connection
+func (n *NATSNotifier) Close() {
+	_ = n.conn.Drain()
+}
+
+// Enabled implements Notifier; every tracker publishes to the bus
+func (n *NATSNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return true
+}
+
+// Notify implements Notifier
+func (n *NATSNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	data, err := json.Marshal(eventRecord(tracker, event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s message: %v", ChannelNATS, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, natsPublishTimeout)
+	defer cancel()
+
+	if n.js != nil {
+		// 스트림의 중복 제거 창 안에서는 재전송된 이벤트가 한 번만 저장됨
+		msg := &nats.Msg{Subject: n.subject, Data: data}
+		if _, err := n.js.PublishMsg(ctx, msg, jetstream.WithMsgID(event.ID)); err != nil {
+			return fmt.Errorf("failed to publish %s notification: %w", ChannelNATS, err)
+		}
+		return nil
+	}
+
+	if err := n.conn.Publish(n.subject, data); err != nil {
+		return fmt.Errorf("failed to publish %s notification: %w", ChannelNATS, err)
+	}
+	// 서버가 메시지를 받았는지 확인
+	if err := n.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to publish %s notification: %w", ChannelNATS, err)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// controllers/notification_queue.go
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	defaultNotificationQueueSize = 100
+	defaultNotificationWorkers   = 2
+	defaultRetryBaseDelay        = time.Second
+	defaultRetryMaxDelay         = time.Minute
+)
+
+// ErrNotificationQueueFull is returned when a notification is dropped because the queue is full
+var ErrNotificationQueueFull = errors.New("notification queue is full")
+
+// deliveryError describes a failed HTTP delivery so the retry policy can act on it
+type deliveryError struct {
+	Channel    string
+	StatusCode int
+	RetryAfter time.Duration
+	// Reason is an error code reported by the API in the response body, if any
+	Reason string
+}
+
+func (e *deliveryError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("%s notification failed with status code: %d: %s", e.Channel, e.StatusCode, e.Reason)
+	}
+	return fmt.Sprintf("%s notification failed with status code: %d", e.Channel, e.StatusCode)
+}
+
+// newDeliveryError builds a deliveryError from a non-2xx response, honouring Retry-After on 429
+func newDeliveryError(channel string, resp *http.Response) *deliveryError {
+	err := &deliveryError{Channel: channel, StatusCode: resp.StatusCode}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds > 0 {
+			err.RetryAfter = time.Duration(seconds) * time.Second
+		}
+	}
+	return err
+}
+
+// isRetryable reports whether a failed delivery may succeed on a later attempt
+func isRetryable(err error) bool {
+	var de *deliveryError
+	if errors.As(err, &de) {
+		return de.StatusCode == http.StatusTooManyRequests || de.StatusCode >= http.StatusInternalServerError
+	}
+	// SMTP 4xx 응답은 일시적 오류, 5xx 응답은 영구적 오류
+	var tpe *textproto.Error
+	if errors.As(err, &tpe) {
+		return tpe.Code < 500
+	}
+	// 네트워크 오류 등은 재시도
+	return true
+}
+
+// notificationJob is one notification to deliver, together with its retry state
+type notificationJob struct {
+	channel    string
+	tracker    types.NamespacedName
+	event      NotificationEvent
+	send       func(ctx context.Context) error
+	attempt    int
+	maxRetries int
+	// done is called once the job was delivered or given up on
+	done func(ctx context.Context)
+	// dropped is called when the job is dropped before either, e.g. because the queue is full
+	dropped func()
+}
+
+// finish calls the job's done callback, if any
+func (job *notificationJob) finish(ctx context.Context) {
+	if job.done != nil {
+		job.done(ctx)
+	}
+}
+
+// NotificationQueue delivers notifications in background workers so that slow or
+// failing endpoints never block Reconcile. Failed deliveries are retried with
+// exponential backoff and jitter, up to the tracker's RetryCount.
+type NotificationQueue struct {
+	jobs      chan *notificationJob
+	workers   int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+}
+
+// NewNotificationQueue creates a queue holding at most size pending notifications
+func NewNotificationQueue(size, workers int) *NotificationQueue {
+	if size <= 0 {
+		size = defaultNotificationQueueSize
+	}
+	if workers <= 0 {
+		workers = defaultNotificationWorkers
+	}
+	return &NotificationQueue{
+		jobs:      make(chan *notificationJob, size),
+		workers:   workers,
+		baseDelay: defaultRetryBaseDelay,
+		maxDelay:  defaultRetryMaxDelay,
+	}
+}
+
+// Enqueue adds a job without blocking; it fails when the queue is full
+func (q *NotificationQueue) Enqueue(job *notificationJob) error {
+	select {
+	case q.jobs <- job:
+		return nil
+	default:
+		return ErrNotificationQueueFull
+	}
+}
+
+// Start runs the delivery workers until ctx is cancelled. It implements manager.Runnable.
+func (q *NotificationQueue) Start(ctx context.Context) error {
+	for i := 0; i < q.workers; i++ {
+		go q.worker(ctx)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (q *NotificationQueue) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-q.jobs:
+			q.process(ctx, job)
+		}
+	}
+}
+
+func (q *NotificationQueue) process(ctx context.Context, job *notificationJob) {
+	logger := ctrl.Log.WithName("notifications").WithValues(
+		"channel", job.channel, "tracker", job.tracker, "attempt", job.attempt+1)
+
+	err := job.send(ctx)
+	if err == nil {
+		job.finish(ctx)
+		return
+	}
+
+	if job.attempt >= job.maxRetries || !isRetryable(err) {
+		logger.Error(err, "Giving up on notification")
+		job.finish(ctx)
+		return
+	}
+
+	delay := q.backoff(job.attempt, err)
+	job.attempt++
+	logger.Info("Notification failed, retrying", "error", err.Error(), "after", delay)
+
+	// 대기 중에도 워커를 점유하지 않도록 타이머로 다시 큐에 넣음
+	time.AfterFunc(delay, func() {
+		if ctx.Err() != nil {
+			if job.dropped != nil {
+				job.dropped()
+			}
+			return
+		}
+		if err := q.Enqueue(job); err != nil {
+			logger.Error(err, "Dropping notification retry")
+			if job.dropped != nil {
+				job.dropped()
+			}
+		}
+	})
+}
+
+// backoff returns the delay before the next attempt. Retry-After from a 429 response
+// wins; otherwise the delay doubles per attempt with equal jitter, capped at maxDelay.
+func (q *NotificationQueue) backoff(attempt int, err error) time.Duration {
+	var de *deliveryError
+	if errors.As(err, &de) && de.RetryAfter > 0 {
+		return de.RetryAfter
+	}
+
+	delay := q.baseDelay << attempt
+	if delay <= 0 || delay > q.maxDelay {
+		delay = q.maxDelay
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// deliverNow sends the job synchronously, sleeping between retries. It is used
+// when the reconciler runs without a NotificationQueue, e.g. in tests.
+func (q *NotificationQueue) deliverNow(ctx context.Context, job *notificationJob) error {
+	for {
+		err := job.send(ctx)
+		if err == nil || job.attempt >= job.maxRetries || !isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(q.backoff(job.attempt, err)):
+		}
+		job.attempt++
+	}
+}
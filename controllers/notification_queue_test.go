@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackNotificationRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	err := postSlackMessage(context.Background(), server.URL, "test message")
+	require.Error(t, err)
+
+	var de *deliveryError
+	require.True(t, errors.As(err, &de))
+	assert.Equal(t, http.StatusTooManyRequests, de.StatusCode)
+	assert.Equal(t, 7*time.Second, de.RetryAfter)
+	assert.True(t, isRetryable(err))
+
+	q := NewNotificationQueue(1, 1)
+	assert.Equal(t, 7*time.Second, q.backoff(0, err))
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, isRetryable(&deliveryError{StatusCode: http.StatusBadGateway}))
+	assert.False(t, isRetryable(&deliveryError{StatusCode: http.StatusNotFound}))
+	assert.True(t, isRetryable(&textproto.Error{Code: 421, Msg: "try again later"}))
+	assert.False(t, isRetryable(&textproto.Error{Code: 550, Msg: "mailbox unavailable"}))
+	assert.True(t, isRetryable(errors.New("connection refused")))
+}
+
+func TestNotificationBackoff(t *testing.T) {
+	q := NewNotificationQueue(1, 1)
+	q.baseDelay = time.Second
+	q.maxDelay = 10 * time.Second
+
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		delay := q.backoff(attempt, errors.New("failed"))
+		assert.GreaterOrEqual(t, delay, max/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, delay, max, "attempt %d", attempt)
+	}
+}
+
+func TestNotificationQueueRetries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := NewNotificationQueue(10, 1)
+	q.baseDelay = time.Millisecond
+	q.maxDelay = 5 * time.Millisecond
+	go q.Start(ctx)
+
+	var attempts atomic.Int32
+	delivered := make(chan struct{})
+	require.NoError(t, q.Enqueue(&notificationJob{
+		channel:    "slack",
+		maxRetries: 3,
+		send: func(context.Context) error {
+			if attempts.Add(1) < 3 {
+				return errors.New("connection reset")
+			}
+			close(delivered)
+			return nil
+		},
+	}))
+
+	select {
+	case <-delivered:
+		assert.Equal(t, int32(3), attempts.Load())
+	case <-time.After(time.Second):
+		t.Fatal("notification was not delivered after retries")
+	}
+}
+
+func TestNotificationQueueGivesUp(t *testing.T) {
+	q := &NotificationQueue{baseDelay: time.Millisecond, maxDelay: time.Millisecond}
+
+	attempts := 0
+	err := q.deliverNow(context.Background(), &notificationJob{
+		channel:    "slack",
+		maxRetries: 2,
+		send: func(context.Context) error {
+			attempts++
+			return &deliveryError{Channel: "slack", StatusCode: http.StatusServiceUnavailable}
+		},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+
+	// 4xx 응답은 재시도하지 않음
+	attempts = 0
+	err = q.deliverNow(context.Background(), &notificationJob{
+		channel:    "slack",
+		maxRetries: 2,
+		send: func(context.Context) error {
+			attempts++
+			return &deliveryError{Channel: "slack", StatusCode: http.StatusForbidden}
+		},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestNotificationQueueFull(t *testing.T) {
+	q := NewNotificationQueue(1, 1)
+	job := &notificationJob{channel: "slack", send: func(context.Context) error { return nil }}
+
+	require.NoError(t, q.Enqueue(job))
+	assert.ErrorIs(t, q.Enqueue(job), ErrNotificationQueueFull)
+}
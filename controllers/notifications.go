@@ -0,0 +1,694 @@
+// controllers/notifications.go
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// notificationHTTPClient is shared by the HTTP based notifiers
+var notificationHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// deploymentRevisionAnnotation is set by the Deployment controller on every rollout
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// Notification event types
+const (
+	EventReady        = "ready"
+	EventNotReady     = "not-ready"
+	EventCompleted    = "completed"
+	EventFailed       = "failed"
+	EventImageChanged = "image-changed"
+	EventScaled       = "scaled"
+	EventDeleted      = "deleted"
+	// EventRecovered is sent when a recorded failure clears, e.g. a Pod leaving
+	// CrashLoopBackOff, which has no ready transition because the Pod stayed Running
+	EventRecovered = "recovered"
+	// EventForgotten is sent to the channels keeping state of a resource, incident
+	// channels and the Slack bot, when the tracker forgets the resource without a
+	// deleted event, e.g. when it no longer matches the selector or a finished Job
+	// was cleaned up, so that its open incident is resolved and its thread dropped
+	EventForgotten = "forgotten"
+
+	// Argo Rollout progress
+	EventRolloutStep     = "rollout-step"
+	EventRolloutPaused   = "rollout-paused"
+	EventAnalysis        = "analysis"
+	EventRolloutAborted  = "rollout-aborted"
+	EventRolloutPromoted = "rollout-promoted"
+)
+
+// NotificationEvent describes a state change of a tracked resource. Message
+// templates, webhook bodies and dashboard URLs are rendered over it.
+type NotificationEvent struct {
+	// Type is one of ready, not-ready, completed, failed, recovered, image-changed, scaled, deleted and forgotten,
+	// or rollout-step, rollout-paused, analysis, rollout-aborted and rollout-promoted for Argo Rollouts
+	Type string `json:"type"`
+	// Severity is critical, warning or info, derived from Type
+	Severity string `json:"severity"`
+	// Kind, Namespace and Name identify the resource
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Labels and Annotations of the resource, used by notification routes
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// ReadyReplicas and TotalReplicas are the ready and desired replicas (1 for Pods),
+	// or the succeeded and required completions of a Job
+	ReadyReplicas int32 `json:"readyReplicas"`
+	TotalReplicas int32 `json:"totalReplicas"`
+	// PreviousReplicas are the desired replicas before a scaled event
+	PreviousReplicas int32 `json:"previousReplicas,omitempty"`
+	// Images are the current container images, PreviousImages those before the last image change
+	Images         []string `json:"images,omitempty"`
+	PreviousImages []string `json:"previousImages,omitempty"`
+	// Phase is Ready, Progressing or Failed, the phase of a Pod, or Running,
+	// Complete or Failed for Jobs
+	Phase string `json:"phase,omitempty"`
+	// Reason and Detail describe a failure
+	Reason string `json:"reason,omitempty"`
+	Detail string `json:"detail,omitempty"`
+	// Revision identifies the rollout, e.g. the Deployment revision
+	Revision string `json:"revision,omitempty"`
+	// Duration is how long the rollout took when the resource became ready,
+	// or how long a finished Job ran
+	Duration time.Duration `json:"duration,omitempty"`
+	// Job describes the progress of a Job
+	Job *JobDetails `json:"job,omitempty"`
+	// Rollout describes the progress of an Argo Rollout
+	Rollout *RolloutDetails `json:"rollout,omitempty"`
+	// Count is the number of events a suppressed summary or a digest stands for
+	Count int `json:"count,omitempty"`
+	// Events are the transitions batched into a digest. For digests ReadyReplicas and
+	// TotalReplicas count the ready and tracked resources, and Progressing lists those not ready.
+	Events      []NotificationEvent `json:"events,omitempty"`
+	Progressing []string            `json:"progressing,omitempty"`
+	// PreviousTransition is when the resource entered the state this event leaves,
+	// e.g. when it stopped being ready before a ready event. It is read from the
+	// tracker status, so it tells a repeated transition apart from the same one
+	// seen again.
+	PreviousTransition time.Time `json:"previousTransition,omitempty"`
+	// ID identifies the transition across reconciles and controller restarts, so
+	// receivers can drop duplicates of an at-least-once delivery
+	ID string `json:"id"`
+	// Message is the rendered human readable message
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func deploymentEvent(deploy *appsv1.Deployment, isReady bool) NotificationEvent {
+	return NotificationEvent{
+		Kind:          "Deployment",
+		Namespace:     deploy.Namespace,
+		Name:          deploy.Name,
+		Labels:        deploy.Labels,
+		Annotations:   deploy.Annotations,
+		ReadyReplicas: deploy.Status.ReadyReplicas,
+		TotalReplicas: *deploy.Spec.Replicas,
+		Images:        containerImages(deploy.Spec.Template.Spec),
+		Phase:         rolloutPhase(isReady),
+		Revision:      deploy.Annotations[deploymentRevisionAnnotation],
+		Timestamp:     time.Now(),
+	}
+}
+
+func statefulSetEvent(sts *appsv1.StatefulSet, isReady bool) NotificationEvent {
+	return NotificationEvent{
+		Kind:          "StatefulSet",
+		Namespace:     sts.Namespace,
+		Name:          sts.Name,
+		Labels:        sts.Labels,
+		Annotations:   sts.Annotations,
+		ReadyReplicas: sts.Status.ReadyReplicas,
+		TotalReplicas: *sts.Spec.Replicas,
+		Images:        containerImages(sts.Spec.Template.Spec),
+		Phase:         rolloutPhase(isReady),
+		Revision:      sts.Status.UpdateRevision,
+		Timestamp:     time.Now(),
+	}
+}
+
+func daemonSetEvent(ds *appsv1.DaemonSet, isReady bool) NotificationEvent {
+	return NotificationEvent{
+		Kind:          "DaemonSet",
+		Namespace:     ds.Namespace,
+		Name:          ds.Name,
+		Labels:        ds.Labels,
+		Annotations:   ds.Annotations,
+		ReadyReplicas: ds.Status.NumberReady,
+		TotalReplicas: ds.Status.DesiredNumberScheduled,
+		Images:        containerImages(ds.Spec.Template.Spec),
+		Phase:         rolloutPhase(isReady),
+		// DaemonSet은 롤아웃 리비전을 노출하지 않으므로 spec 세대로 구분
+		Revision:  strconv.FormatInt(ds.Generation, 10),
+		Timestamp: time.Now(),
+	}
+}
+
+func podEvent(pod *corev1.Pod, isReady bool) NotificationEvent {
+	return NotificationEvent{
+		Kind:          "Pod",
+		Namespace:     pod.Namespace,
+		Name:          pod.Name,
+		Labels:        pod.Labels,
+		Annotations:   pod.Annotations,
+		ReadyReplicas: boolToInt32(isReady),
+		TotalReplicas: 1,
+		Images:        containerImages(pod.Spec),
+		Phase:         string(pod.Status.Phase),
+		Timestamp:     time.Now(),
+	}
+}
+
+func rolloutPhase(isReady bool) string {
+	if isReady {
+		return "Ready"
+	}
+	return "Progressing"
+}
+
+func containerImages(spec corev1.PodSpec) []string {
+	images := make([]string, 0, len(spec.Containers))
+	for _, c := range spec.Containers {
+		images = append(images, c.Image)
+	}
+	return images
+}
+
+// recordImages stores the resource's current images in the tracker status and returns
+// the images it ran before the last image change. changed is true if the status was modified.
+func recordImages(tracker *ddukbgv1alpha1.ResourceTracker, key string, images []string) (previous []string, changed bool) {
+	current := strings.Join(images, ",")
+
+	for i := range tracker.Status.ResourceStates {
+		state := &tracker.Status.ResourceStates[i]
+		if state.Name != key {
+			continue
+		}
+		if state.CurrentImage != current {
+			state.PreviousImage = state.CurrentImage
+			state.CurrentImage = current
+			changed = true
+		}
+		if state.PreviousImage == "" {
+			return nil, changed
+		}
+		return strings.Split(state.PreviousImage, ","), changed
+	}
+
+	tracker.Status.ResourceStates = append(tracker.Status.ResourceStates, ddukbgv1alpha1.ResourceState{
+		Name:         key,
+		CurrentImage: current,
+	})
+	return nil, true
+}
+
+// resourceChange is what recordResourceState observed about a resource
+type resourceChange struct {
+	// statusChanged is true if the tracker status was modified
+	statusChanged bool
+	// imageChanged is true if the images differ from the last observed images
+	imageChanged bool
+	// scaled is true if the desired replicas differ from the last observed replicas
+	scaled bool
+}
+
+// recordResourceState updates the readiness transition time, image history and
+// observed replicas of the resource, and fills in event.PreviousImages,
+// event.PreviousReplicas and event.Duration.
+func recordResourceState(tracker *ddukbgv1alpha1.ResourceTracker, key string, isReady bool, event *NotificationEvent) resourceChange {
+	since, seen := tracker.Status.TransitionTimes[key]
+	event.PreviousTransition = since.Time
+	transitioned := recordTransition(tracker, key, isReady)
+	if transitioned && isReady && seen {
+		// 마지막으로 Ready가 아니게 된 시점부터 Ready가 될 때까지의 시간
+		event.Duration = time.Since(since.Time).Round(time.Second)
+	}
+
+	previous, imagesChanged := recordImages(tracker, key, event.Images)
+	event.PreviousImages = previous
+
+	previousReplicas, observed := tracker.Status.ObservedReplicas[key]
+	replicasChanged := !observed || previousReplicas != event.TotalReplicas
+	if replicasChanged {
+		if tracker.Status.ObservedReplicas == nil {
+			tracker.Status.ObservedReplicas = make(map[string]int32)
+		}
+		tracker.Status.ObservedReplicas[key] = event.TotalReplicas
+		event.PreviousReplicas = previousReplicas
+	}
+
+	return resourceChange{
+		statusChanged: transitioned || imagesChanged || replicasChanged,
+		// 처음 관찰한 리소스는 변경으로 보지 않음
+		imageChanged: imagesChanged && previous != nil,
+		scaled:       replicasChanged && observed,
+	}
+}
+
+// notifyChanges sends image-changed and scaled events for the observed changes
+func (r *ResourceTrackerReconciler) notifyChanges(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	event NotificationEvent, change resourceChange) {
+	if change.imageChanged {
+		event.Type = EventImageChanged
+		if err := r.sendNotifications(ctx, tracker, event); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to send image change notification")
+		}
+	}
+	if change.scaled {
+		event.Type = EventScaled
+		if err := r.sendNotifications(ctx, tracker, event); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to send scale notification")
+		}
+	}
+}
+
+// forgetDeletedResources sends a deleted event for every tracked resource whose key
+// is not in present, and removes its state. Resources that still exist but no longer
+// match the target's selector or namespaces are forgotten without an event. It returns true if the
+// status was modified.
+func (r *ResourceTrackerReconciler) forgetDeletedResources(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	present map[string]bool) bool {
+	changed := false
+	for key := range trackedKeys(tracker) {
+		if present[key] {
+			continue
+		}
+		namespace, name, _ := strings.Cut(key, "/")
+		if !singleResource(tracker.Spec.Target) {
+			// 레이블이 바뀌어 셀렉터나 대상 네임스페이스에서 빠진 리소스는 삭제 알림 없이 잊음
+			exists, err := r.targetExists(ctx, tracker.Spec.Target, types.NamespacedName{Namespace: namespace, Name: name})
+			if err != nil {
+				log.FromContext(ctx).Error(err, "Failed to check whether the resource was deleted", "resource", key)
+				continue
+			}
+			if exists {
+				r.forgetUntracked(ctx, tracker, key, tracker.Spec.Target.Kind)
+				changed = true
+				continue
+			}
+		}
+		changed = true
+
+		event := NotificationEvent{
+			Type:      EventDeleted,
+			Kind:      tracker.Spec.Target.Kind,
+			Namespace: namespace,
+			Name:      name,
+			// 같은 이름으로 다시 만들어진 리소스의 삭제와 구분
+			PreviousTransition: tracker.Status.TransitionTimes[key].Time,
+			Images:             forgetResource(tracker, key),
+			Timestamp:          time.Now(),
+		}
+		if err := r.sendNotifications(ctx, tracker, event); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to send deletion notification")
+		}
+	}
+	return changed
+}
+
+// trackedKeys returns the keys of every resource the tracker status records
+func trackedKeys(tracker *ddukbgv1alpha1.ResourceTracker) map[string]bool {
+	// 아직 한 번도 Ready가 아니었던 리소스는 TransitionTimes에만 기록되어 있음
+	tracked := make(map[string]bool, len(tracker.Status.TransitionTimes))
+	for key := range tracker.Status.ResourceStatus {
+		tracked[key] = true
+	}
+	for key := range tracker.Status.TransitionTimes {
+		tracked[key] = true
+	}
+	return tracked
+}
+
+// forgetUntracked removes the state of a resource the tracker stops watching without
+// a deleted event. When the resource failed or has a Slack thread, the channels
+// keeping its state are told first, while the failure is still recorded.
+func (r *ResourceTrackerReconciler) forgetUntracked(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	key, kind string) {
+	reason, failed := tracker.Status.Failures[key]
+	_, open := tracker.Status.PagerDutyIncidents[key]
+	if _, threaded := tracker.Status.SlackThreads[key]; failed || open || threaded {
+		namespace, name, _ := strings.Cut(key, "/")
+		event := NotificationEvent{
+			Type:               EventForgotten,
+			Kind:               kind,
+			Namespace:          namespace,
+			Name:               name,
+			Reason:             reason,
+			PreviousTransition: tracker.Status.FailureTimes[key].Time,
+			Timestamp:          time.Now(),
+		}
+		if err := r.sendNotifications(ctx, tracker, event); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to resolve the incident of an untracked resource", "resource", key)
+		}
+	}
+	forgetResource(tracker, key)
+}
+
+// forgetResource removes the state of a tracked resource and returns its last
+// observed images
+func forgetResource(tracker *ddukbgv1alpha1.ResourceTracker, key string) []string {
+	var images []string
+	for i, state := range tracker.Status.ResourceStates {
+		if state.Name == key {
+			if state.CurrentImage != "" {
+				images = strings.Split(state.CurrentImage, ",")
+			}
+			tracker.Status.ResourceStates = append(tracker.Status.ResourceStates[:i], tracker.Status.ResourceStates[i+1:]...)
+			break
+		}
+	}
+
+	delete(tracker.Status.ResourceStatus, key)
+	delete(tracker.Status.TransitionTimes, key)
+	delete(tracker.Status.Failures, key)
+	delete(tracker.Status.FailureTimes, key)
+	delete(tracker.Status.ObservedReplicas, key)
+	delete(tracker.Status.ConsecutiveFailures, key)
+	delete(tracker.Status.Rollouts, key)
+	// 열린 인시던트와 스레드는 각 알림이 정리하고, 해당 알림을 쓰지 않게 되었으면 바로 지움
+	if tracker.Spec.Notify.PagerDuty == nil {
+		delete(tracker.Status.PagerDutyIncidents, key)
+	}
+	if tracker.Spec.Notify.SlackBot == nil {
+		delete(tracker.Status.SlackThreads, key)
+	}
+	return images
+}
+
+// truncate shortens s to at most limit bytes without splitting a UTF-8 character
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+	return s[:limit]
+}
+
+// incidentKey identifies the incident of a resource watched by a tracker, so that
+// repeated failures of the same resource update one PagerDuty incident or Opsgenie alert
+func incidentKey(trackerKey types.NamespacedName, key string) string {
+	return fmt.Sprintf("k8s-deploy-watcher/%s/%s", trackerKey, key)
+}
+
+// sendNotifications fans the event out to every notifier enabled on the tracker
+// that the tracker's routes select, or adds it to the tracker's digest. Non-critical
+// events are held back during the tracker's quiet hours and maintenance windows.
+func (r *ResourceTrackerReconciler) sendNotifications(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	var errs []error
+	registry := r.notifiers()
+	trackerKey := client.ObjectKeyFromObject(tracker)
+	if event.Severity == "" {
+		event.Severity = eventSeverity(event.Type)
+	}
+	duplicate := r.Throttle != nil && r.Throttle.Duplicate(trackerKey, event)
+	if duplicate {
+		log.FromContext(ctx).V(1).Info("Skipping duplicate notification except on incident channels", "event", event.Type,
+			"resource", event.Namespace+"/"+event.Name)
+	} else if batch := outboxBatchFrom(ctx); batch != nil && r.Throttle != nil {
+		// 기록에 실패해 전환을 다시 감지하면 중복으로 걸러지지 않도록 되돌림
+		batch.onFailure(func() { r.Throttle.Forget(trackerKey, event) })
+	}
+	event.Message = renderMessage(ctx, tracker, event)
+	routed := routeChannels(ctx, tracker, event)
+	window := digestWindow(tracker)
+	var digested, held []string
+	var quietUntil time.Time
+	quietAction, quiet := "", false
+	if event.Severity != SeverityCritical {
+		quietUntil, quietAction, quiet = r.quietHours(ctx, tracker, time.Now())
+	}
+	// 비동기 전송 중에 tracker가 변경되지 않도록 복사본 사용
+	snapshot := tracker.DeepCopy()
+
+	for _, channel := range registry.Channels() {
+		// forgotten은 라우트에 지정할 수 없고 열린 인시던트만 닫으므로 라우트와 관계없이 전달
+		if routed != nil && !routed[channel] && !eventBusChannels[channel] && event.Type != EventForgotten {
+			continue
+		}
+		// 인시던트 채널은 자체적으로 중복을 처리하므로 페이지를 놓치지 않도록 항상 전달
+		if duplicate && !incidentChannels[channel] {
+			continue
+		}
+		notifier, _ := registry.Get(channel)
+		if !notifier.Enabled(snapshot) {
+			continue
+		}
+		if event.Type == EventNotReady && !transitionChannels[channel] &&
+			!(threadedChannels[channel] && event.Revision != "") {
+			continue
+		}
+		// 복구 이벤트는 인시던트를 닫는 채널과 이벤트 싱크에만 전달
+		if event.Type == EventRecovered && !unbatched(channel) {
+			continue
+		}
+		if event.Type == EventForgotten && !incidentChannels[channel] && !threadedChannels[channel] {
+			continue
+		}
+		// 진행 상황은 롤아웃 스레드의 답글로만 의미가 있으므로 다이제스트에 넣지 않음
+		if event.Type == EventNotReady && (quiet || window > 0) && !transitionChannels[channel] {
+			continue
+		}
+		if quiet && !unbatched(channel) {
+			// 조용한 시간에는 버리거나 끝난 뒤 다이제스트로 발송
+			if quietAction == QuietActionDigest {
+				held = append(held, channel)
+			}
+			continue
+		}
+		if window > 0 && !unbatched(channel) {
+			digested = append(digested, channel)
+			continue
+		}
+
+		job := r.notificationJob(snapshot, channel, notifier, event)
+		if r.Throttle != nil && !unbatched(channel) {
+			summaryJob := func(summary NotificationEvent) {
+				summary.Namespace = describeNamespaces(snapshot.Spec.Target)
+				if err := r.dispatch(context.Background(), r.notificationJob(snapshot, channel, notifier, summary)); err != nil {
+					log.FromContext(ctx).Error(err, "Failed to send suppressed notification summary", "channel", channel)
+				}
+			}
+			if !r.Throttle.Allow(trackerKey.String()+"/"+channel, event, summaryJob) {
+				log.FromContext(ctx).V(1).Info("Rate limited notification", "channel", channel,
+					"event", event.Type, "resource", event.Namespace+"/"+event.Name)
+				continue
+			}
+		}
+		if err := r.dispatch(ctx, job); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+		}
+	}
+
+	if len(digested) > 0 {
+		r.digests().Add(trackerKey, "", window, event, digested, r.sendDigest)
+	}
+	if len(held) > 0 {
+		if r.Outbox != nil {
+			if err := r.hold(ctx, snapshot, event, held, quietUntil); err != nil {
+				errs = append(errs, err)
+			}
+		} else {
+			r.digests().Add(trackerKey, quietDigestGroup, time.Until(quietUntil), event, held, r.sendDigest)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notificationJob builds the job delivering event through one notifier
+func (r *ResourceTrackerReconciler) notificationJob(snapshot *ddukbgv1alpha1.ResourceTracker, channel string,
+	notifier Notifier, event NotificationEvent) *notificationJob {
+	trackerKey := client.ObjectKeyFromObject(snapshot)
+	if event.ID == "" {
+		event.ID = eventID(trackerKey, event)
+	}
+	return &notificationJob{
+		channel:    channel,
+		tracker:    trackerKey,
+		event:      event,
+		maxRetries: snapshot.Spec.Notify.RetryCount,
+		send: func(ctx context.Context) error {
+			return notifier.Notify(ctx, snapshot, event)
+		},
+	}
+}
+
+// notifiers returns the configured registry, falling back to the built-in channels
+func (r *ResourceTrackerReconciler) notifiers() *NotifierRegistry {
+	if r.Notifiers == nil {
+		r.Notifiers = NewDefaultNotifierRegistry(r.Client)
+	}
+	return r.Notifiers
+}
+
+// dispatch records the job in the outbox and hands it to the notification queue,
+// or delivers it inline when there is no queue. Within a Reconcile, recorded jobs
+// are delivered once persistOutbox wrote them; otherwise they are written first.
+func (r *ResourceTrackerReconciler) dispatch(ctx context.Context, job *notificationJob) error {
+	if r.Outbox == nil {
+		return r.deliver(ctx, job)
+	}
+
+	key := job.event.ID + "." + job.channel
+	batch := outboxBatchFrom(ctx)
+	added, err := r.Outbox.Add(ctx, OutboxEntry{
+		Key:       key,
+		Channel:   job.channel,
+		Tracker:   job.tracker.String(),
+		Event:     job.event,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		// 기록하지 못한 알림은 보내지 않고, Reconcile을 실패시켜 전환을 다시 감지
+		if batch != nil {
+			batch.fail(err)
+		}
+		return fmt.Errorf("failed to record notification in the outbox: %w", err)
+	}
+	if !added {
+		log.FromContext(ctx).V(1).Info("Notification already in the outbox", "key", key)
+		return nil
+	}
+	r.trackInOutbox(job, key)
+
+	if batch != nil {
+		batch.add(key, job)
+		return nil
+	}
+	if err := r.Outbox.Persist(ctx, []string{key}); err != nil {
+		r.Outbox.Discard([]string{key})
+		return err
+	}
+	return r.deliver(ctx, job)
+}
+
+// persistOutbox writes the notifications recorded by the current Reconcile to the
+// outbox and only then delivers them. It must succeed before the tracker status
+// recording their transitions is written: on failure the notifications are
+// dropped, so that the next Reconcile detects the transitions again.
+func (r *ResourceTrackerReconciler) persistOutbox(ctx context.Context) error {
+	batch := outboxBatchFrom(ctx)
+	if batch == nil {
+		return nil
+	}
+	keys, jobs, undo, err := batch.take()
+	if err == nil && len(keys) > 0 {
+		err = r.Outbox.Persist(ctx, keys)
+	}
+	if err != nil {
+		r.Outbox.Discard(keys)
+		for _, f := range undo {
+			f()
+		}
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := r.deliver(ctx, job); err != nil {
+			// 전송 큐가 가득 찬 알림은 outbox에 남아 있으므로 나중에 다시 전송됨
+			log.FromContext(ctx).Error(err, "Failed to queue notification", "channel", job.channel)
+		}
+	}
+	return nil
+}
+
+// trackInOutbox completes the job's outbox entry once it is delivered, and releases
+// it for replay when the job is dropped
+func (r *ResourceTrackerReconciler) trackInOutbox(job *notificationJob, key string) {
+	outbox := r.Outbox
+	job.done = func(ctx context.Context) {
+		if err := outbox.Complete(ctx, key); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to complete outbox entry", "key", key)
+		}
+	}
+	job.dropped = func() { outbox.Release(key) }
+}
+
+// deliver hands the job to the notification queue, or delivers it inline when there is none
+func (r *ResourceTrackerReconciler) deliver(ctx context.Context, job *notificationJob) error {
+	if r.Notifications != nil {
+		err := r.Notifications.Enqueue(job)
+		if err != nil && job.dropped != nil {
+			job.dropped()
+		}
+		return err
+	}
+	inline := &NotificationQueue{baseDelay: defaultRetryBaseDelay, maxDelay: defaultRetryMaxDelay}
+	err := inline.deliverNow(ctx, job)
+	job.finish(ctx)
+	return err
+}
+
+// redeliver delivers an outbox entry left pending, e.g. by a controller restart
+func (r *ResourceTrackerReconciler) redeliver(ctx context.Context, entry OutboxEntry) error {
+	namespace, name, _ := strings.Cut(entry.Tracker, "/")
+	tracker := &ddukbgv1alpha1.ResourceTracker{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, tracker)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	notifier, ok := r.notifiers().Get(entry.Channel)
+	if err != nil || !ok || !notifier.Enabled(tracker) {
+		// 트래커나 채널이 사라졌으면 전송할 곳이 없음
+		return r.Outbox.Complete(ctx, entry.Key)
+	}
+
+	job := r.notificationJob(tracker, entry.Channel, notifier, entry.Event)
+	r.trackInOutbox(job, entry.Key)
+	return r.deliver(ctx, job)
+}
+
+// postJSON sends payload as JSON to url and treats any non-2xx response as a delivery error
+func postJSON(ctx context.Context, channel, url string, payload interface{}) error {
+	return sendJSON(ctx, channel, http.MethodPost, url, nil, payload)
+}
+
+// sendJSON sends payload as JSON with the given method and extra headers,
+// treating any non-2xx response as a delivery error
+func sendJSON(ctx context.Context, channel, method, url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s message: %v", channel, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %v", channel, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := notificationHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", channel, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newDeliveryError(channel, resp)
+	}
+	return nil
+}
@@ -1,42 +1,715 @@
 package controllers
 
 import (
-	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
-func TestSlackNotification(t *testing.T) {
-	// 원래 함수 백업
-	originalSendSlack := sendSlackNotification
-	defer func() {
-		sendSlackNotification = originalSendSlack
-	}()
+// fakeNotifier records the events it receives instead of delivering them
+type fakeNotifier struct {
+	events  chan NotificationEvent
+	enabled func(tracker *ddukbgv1alpha1.ResourceTracker) bool
+	err     error
+}
+
+func newFakeNotifier() *fakeNotifier {
+	return &fakeNotifier{events: make(chan NotificationEvent, 10)}
+}
+
+func (n *fakeNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return n.enabled == nil || n.enabled(tracker)
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	n.events <- event
+	return n.err
+}
 
+// newFakeRegistry returns a registry holding only the given notifier
+func newFakeRegistry(channel string, notifier Notifier) *NotifierRegistry {
+	registry := NewNotifierRegistry()
+	registry.Register(channel, notifier)
+	return registry
+}
+
+func TestSlackNotification(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte("Internal Server Error"))
 	}))
 	defer server.Close()
 
-	// 실제 HTTP 요청을 하는 함수로 교체
-	sendSlackNotification = func(webhookURL string, message string) error {
-		resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer([]byte(message)))
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: server.URL},
+		},
+	}
+
+	notifier := &SlackNotifier{}
+	require.True(t, notifier.Enabled(tracker))
+
+	err := notifier.Notify(context.Background(), tracker, NotificationEvent{Message: "test message"})
+	assert.Error(t, err)
+}
+
+func TestSendNotificationsFanOut(t *testing.T) {
+	slack := newFakeNotifier()
+	email := newFakeNotifier()
+	email.err = errors.New("smtp unavailable")
+	disabled := newFakeNotifier()
+	disabled.enabled = func(*ddukbgv1alpha1.ResourceTracker) bool { return false }
+
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, slack)
+	registry.Register(ChannelEmail, email)
+	registry.Register(ChannelWebhook, disabled)
+	assert.Equal(t, []string{ChannelSlack, ChannelEmail, ChannelWebhook}, registry.Channels())
+
+	r := &ResourceTrackerReconciler{Notifiers: registry}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+	}
+
+	event := NotificationEvent{Type: EventReady, Kind: "Deployment", Namespace: "default", Name: "test-app",
+		ReadyReplicas: 3, TotalReplicas: 3}
+	err := r.sendNotifications(context.Background(), tracker, event)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "email: smtp unavailable")
+
+	expected := readyMessage("Deployment", "default", "test-app", 3, 3)
+	assert.Equal(t, expected, (<-slack.events).Message)
+	assert.Equal(t, expected, (<-email.events).Message)
+	assert.Empty(t, disabled.events)
+}
+
+func TestSlackBlocksMessage(t *testing.T) {
+	received := make(chan SlackMessage, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg SlackMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		received <- msg
+	}))
+	defer server.Close()
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				Slack:        server.URL,
+				SlackStyle:   SlackStyleBlocks,
+				DashboardURL: "https://grafana.example.com/d/app?var-namespace={{ .Namespace }}&var-name={{ .Name }}",
+			},
+		},
+	}
+	event := NotificationEvent{
+		Type:           EventReady,
+		Kind:           "Deployment",
+		Namespace:      "default",
+		Name:           "test-app",
+		ReadyReplicas:  3,
+		TotalReplicas:  3,
+		Images:         []string{"nginx:1.25"},
+		PreviousImages: []string{"nginx:1.24"},
+		Duration:       90 * time.Second,
+		Message:        readyMessage("Deployment", "default", "test-app", 3, 3),
+		Timestamp:      time.Now(),
+	}
+
+	require.NoError(t, (&SlackNotifier{}).Notify(context.Background(), tracker, event))
+
+	msg := <-received
+	assert.Equal(t, event.Message, msg.Text)
+	require.Len(t, msg.Attachments, 1)
+	attachment := msg.Attachments[0]
+	assert.Equal(t, slackColorReady, attachment.Color)
+
+	require.Len(t, attachment.Blocks, 4)
+	assert.Equal(t, "*Deployment default/test-app is now ready*", attachment.Blocks[0].Text.Text)
+
+	var fields []string
+	for _, field := range attachment.Blocks[1].Fields {
+		fields = append(fields, field.Text)
+	}
+	assert.Contains(t, fields, "*Replicas*\n3/3 ready")
+	assert.Contains(t, fields, "*Image*\nnginx:1.24 → nginx:1.25")
+	assert.Contains(t, fields, "*Rollout duration*\n1m30s")
 
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("slack notification failed: %d", resp.StatusCode)
+	assert.Equal(t, "actions", attachment.Blocks[2].Type)
+	assert.Equal(t, "https://grafana.example.com/d/app?var-namespace=default&var-name=test-app",
+		attachment.Blocks[2].Elements[0].URL)
+
+	// 실패 이벤트는 빨간색, 잘못된 대시보드 템플릿은 버튼만 생략
+	event.Type = EventFailed
+	tracker.Spec.Notify.DashboardURL = "{{ .Missing }}"
+	require.NoError(t, (&SlackNotifier{}).Notify(context.Background(), tracker, event))
+
+	msg = <-received
+	assert.Equal(t, slackColorFailed, msg.Attachments[0].Color)
+	assert.Len(t, msg.Attachments[0].Blocks, 3)
+}
+
+func TestSlackBotThreads(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	var posted []slackPostMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.postMessage", r.URL.Path)
+		assert.Equal(t, "Bearer xoxb-test", r.Header.Get("Authorization"))
+
+		var msg slackPostMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		posted = append(posted, msg)
+		json.NewEncoder(w).Encode(slackAPIResponse{OK: true, TS: fmt.Sprintf("1700000000.%06d", len(posted))})
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack-bot", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("xoxb-test")},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				SlackBot: &ddukbgv1alpha1.SlackBotConfig{
+					TokenSecretRef: ddukbgv1alpha1.SecretKeyRef{Name: "slack-bot", Key: "token"},
+					Channel:        "#deploys",
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(secret, tracker).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+
+	notifier := NewSlackBotNotifier(c)
+	notifier.APIURL = server.URL
+	ctx := context.Background()
+	event := NotificationEvent{Kind: "Deployment", Namespace: "default", Name: "test-app", Revision: "2"}
+
+	// 첫 이벤트는 부모 메시지를 만들고 스레드에 답글로 게시
+	event.Type, event.Message = EventFailed, "failed"
+	require.NoError(t, notifier.Notify(ctx, tracker, event))
+	require.Len(t, posted, 2)
+	assert.Contains(t, posted[0].Text, "Rollout of Deployment default/test-app (revision 2)")
+	assert.Empty(t, posted[0].ThreadTS)
+	assert.Equal(t, "1700000000.000001", posted[1].ThreadTS)
+	assert.True(t, posted[1].ReplyBroadcast)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tracker), updated))
+	assert.Equal(t, ddukbgv1alpha1.SlackThread{Revision: "2", Channel: "#deploys", TS: "1700000000.000001"},
+		updated.Status.SlackThreads["default/test-app"])
+
+	// 재시작 후에도 status에 기록된 같은 스레드에 이어서 게시
+	restarted := NewSlackBotNotifier(c)
+	restarted.APIURL = server.URL
+	event.Type, event.Message = EventReady, "ready"
+	require.NoError(t, restarted.Notify(ctx, updated, event))
+	require.Len(t, posted, 3)
+	assert.Equal(t, "1700000000.000001", posted[2].ThreadTS)
+	assert.False(t, posted[2].ReplyBroadcast)
+
+	// 새 리비전은 새 스레드
+	event.Revision = "3"
+	require.NoError(t, restarted.Notify(ctx, updated, event))
+	require.Len(t, posted, 5)
+	assert.Contains(t, posted[3].Text, "(revision 3)")
+	assert.Equal(t, "1700000000.000004", posted[4].ThreadTS)
+
+	// 새 리비전의 첫 not-ready 이벤트가 스레드를 열고 진행 상황은 답글로만 게시
+	event.Revision = "4"
+	event.Type, event.Message = EventNotReady, "not ready"
+	require.NoError(t, restarted.Notify(ctx, updated, event))
+	require.Len(t, posted, 7)
+	assert.Contains(t, posted[5].Text, "(revision 4)")
+	assert.Equal(t, "1700000000.000006", posted[6].ThreadTS)
+	assert.False(t, posted[6].ReplyBroadcast)
+	event.Type, event.Message = EventReady, "ready"
+	require.NoError(t, restarted.Notify(ctx, updated, event))
+	require.Len(t, posted, 8)
+	assert.Equal(t, "1700000000.000006", posted[7].ThreadTS)
+
+	// 리비전이 없는 Pod의 not-ready는 채널에 게시하지 않음
+	pod := NotificationEvent{Type: EventNotReady, Kind: "Pod", Namespace: "default", Name: "test-pod"}
+	require.NoError(t, restarted.Notify(ctx, updated, pod))
+	assert.Len(t, posted, 8)
+
+	// Ready로 끝난 롤아웃의 스레드는 정리
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tracker), updated))
+	assert.Empty(t, updated.Status.SlackThreads)
+	assert.Empty(t, restarted.threads)
+
+	// 실패한 채 남은 스레드는 리소스를 더 이상 추적하지 않으면 게시 없이 정리
+	event.Revision = "5"
+	event.Type, event.Message = EventFailed, "failed"
+	require.NoError(t, restarted.Notify(ctx, updated, event))
+	require.Len(t, posted, 10)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tracker), updated))
+	require.Contains(t, updated.Status.SlackThreads, "default/test-app")
+	forgotten := NotificationEvent{Type: EventForgotten, Kind: "Deployment", Namespace: "default", Name: "test-app"}
+	require.NoError(t, restarted.Notify(ctx, updated, forgotten))
+	assert.Len(t, posted, 10)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tracker), updated))
+	assert.Empty(t, updated.Status.SlackThreads)
+	assert.Empty(t, restarted.threads)
+}
+
+func TestKeyedMutex(t *testing.T) {
+	var locks keyedMutex
+	unlockA := locks.Lock("a")
+
+	// 다른 키는 기다리지 않음
+	done := make(chan struct{})
+	go func() {
+		locks.Lock("b")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a different key was blocked")
+	}
+
+	// 같은 키는 풀릴 때까지 기다림
+	acquired := make(chan func())
+	go func() { acquired <- locks.Lock("a") }()
+	select {
+	case <-acquired:
+		t.Fatal("the same key was not serialized")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlockA()
+	(<-acquired)()
+	assert.Empty(t, locks.locks, "unused locks are dropped")
+}
+
+func TestSendNotificationsSlackBotProgress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tracker).Build()
+
+	slack, slackBot := newFakeNotifier(), newFakeNotifier()
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, slack)
+	registry.Register(ChannelSlackBot, slackBot)
+	r := &ResourceTrackerReconciler{Client: c, Notifiers: registry}
+
+	// 롤아웃 중의 not-ready는 스레드를 쓰는 slack-bot에만 전달
+	ctx := context.Background()
+	event := NotificationEvent{Type: EventNotReady, Kind: "Deployment", Namespace: "default", Name: "web", Revision: "2"}
+	require.NoError(t, r.sendNotifications(ctx, tracker, event))
+	assert.Len(t, slack.events, 0)
+	require.Len(t, slackBot.events, 1)
+	assert.Equal(t, EventNotReady, (<-slackBot.events).Type)
+
+	// 리비전이 없으면 스레드가 없으므로 전달하지 않음
+	event = NotificationEvent{Type: EventNotReady, Kind: "Pod", Namespace: "default", Name: "web-1"}
+	require.NoError(t, r.sendNotifications(ctx, tracker, event))
+	assert.Len(t, slack.events, 0)
+	assert.Len(t, slackBot.events, 0)
+}
+
+func TestSlackBotAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(slackAPIResponse{OK: false, Error: "channel_not_found"})
+	}))
+	defer server.Close()
+
+	notifier := &SlackBotNotifier{APIURL: server.URL}
+	_, err := notifier.postMessage(context.Background(), "xoxb-test", slackPostMessage{Channel: "#missing", Text: "test"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "channel_not_found")
+	assert.False(t, isRetryable(err))
+}
+
+func TestTeamsNotification(t *testing.T) {
+	received := make(chan TeamsMessage, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg TeamsMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		received <- msg
+	}))
+	defer server.Close()
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{Teams: server.URL},
+		},
+	}
+	event := NotificationEvent{
+		Type:          EventReady,
+		Kind:          "Deployment",
+		Namespace:     "default",
+		Name:          "test-app",
+		ReadyReplicas: 3,
+		TotalReplicas: 3,
+		Images:        []string{"nginx:1.25"},
+		Phase:         "Ready",
+	}
+
+	notifier := &TeamsNotifier{}
+	require.True(t, notifier.Enabled(tracker))
+	require.NoError(t, notifier.Notify(context.Background(), tracker, event))
+
+	msg := <-received
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", msg.Attachments[0].ContentType)
+	card := msg.Attachments[0].Content
+	assert.Equal(t, "AdaptiveCard", card.Type)
+	assert.Equal(t, "Deployment default/test-app is now ready", card.Body[0].Text)
+	assert.Equal(t, "Good", card.Body[0].Color)
+	assert.Contains(t, card.Body[1].Facts, AdaptiveCardFact{Title: "Replicas", Value: "3/3 ready"})
+	assert.Contains(t, card.Body[1].Facts, AdaptiveCardFact{Title: "Image", Value: "nginx:1.25"})
+
+	// 실패 응답은 Slack과 같은 재시도 정책을 따름
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	tracker.Spec.Notify.Teams = failing.URL
+	err := notifier.Notify(context.Background(), tracker, event)
+	require.Error(t, err)
+	assert.True(t, isRetryable(err))
+}
+
+func TestPagerDutyLifecycle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	var received []PagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event PagerDutyEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		received = append(received, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pagerduty", Namespace: "default"},
+		Data:       map[string][]byte{"routingKey": []byte("R0UT1NGKEY")},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				AlertOnFail: true,
+				PagerDuty: &ddukbgv1alpha1.PagerDutyConfig{
+					RoutingKeySecretRef: ddukbgv1alpha1.SecretKeyRef{Name: "pagerduty", Key: "routingKey"},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(secret, tracker).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+
+	notifier := NewPagerDutyNotifier(c)
+	notifier.EventsURL = server.URL
+	ctx := context.Background()
+	event := NotificationEvent{Kind: "Deployment", Namespace: "default", Name: "test-app"}
+
+	// 복구할 인시던트가 없으면 resolve를 보내지 않음
+	event.Type = EventReady
+	require.NoError(t, notifier.Notify(ctx, tracker, event))
+	assert.Empty(t, received)
+
+	event.Type, event.Reason = EventFailed, "ProgressDeadlineExceeded"
+	require.NoError(t, notifier.Notify(ctx, tracker, event))
+	require.Len(t, received, 1)
+	trigger := received[0]
+	assert.Equal(t, "trigger", trigger.EventAction)
+	assert.Equal(t, "R0UT1NGKEY", trigger.RoutingKey)
+	assert.Equal(t, "k8s-deploy-watcher/default/test-tracker/default/test-app", trigger.DedupKey)
+	assert.Equal(t, "Deployment default/test-app has failed: ProgressDeadlineExceeded", trigger.Payload.Summary)
+	assert.Equal(t, "error", trigger.Payload.Severity)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tracker), updated))
+	assert.Equal(t, trigger.DedupKey, updated.Status.PagerDutyIncidents["default/test-app"])
+
+	event.Type, event.Reason = EventReady, ""
+	require.NoError(t, notifier.Notify(ctx, tracker, event))
+	require.Len(t, received, 2)
+	assert.Equal(t, "resolve", received[1].EventAction)
+	assert.Equal(t, trigger.DedupKey, received[1].DedupKey)
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tracker), updated))
+	assert.Empty(t, updated.Status.PagerDutyIncidents)
+
+	// 삭제되거나 더 이상 추적하지 않는 리소스의 인시던트도 resolve
+	for i, eventType := range []string{EventDeleted, EventForgotten} {
+		event.Type, event.Reason = EventFailed, "ProgressDeadlineExceeded"
+		require.NoError(t, notifier.Notify(ctx, tracker, event))
+		event.Type, event.Reason = eventType, ""
+		require.NoError(t, notifier.Notify(ctx, tracker, event))
+		require.Len(t, received, 4+2*i)
+		assert.Equal(t, "resolve", received[3+2*i].EventAction, eventType)
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tracker), updated))
+		assert.Empty(t, updated.Status.PagerDutyIncidents, eventType)
+	}
+}
+
+func TestPagerDutyIncidentsDoNotBlockEachOther(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	// api의 트리거는 응답을 붙잡아 둠
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event PagerDutyEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		if strings.HasSuffix(event.DedupKey, "/default/api") {
+			<-release
 		}
-		return nil
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pagerduty", Namespace: "default"},
+		Data:       map[string][]byte{"routingKey": []byte("R0UT1NGKEY")},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				PagerDuty: &ddukbgv1alpha1.PagerDutyConfig{
+					RoutingKeySecretRef: ddukbgv1alpha1.SecretKeyRef{Name: "pagerduty", Key: "routingKey"},
+				},
+			},
+		},
 	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(secret, tracker).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
 
-	err := sendSlackNotification(server.URL, "test message")
-	assert.Error(t, err)
+	notifier := NewPagerDutyNotifier(c)
+	notifier.EventsURL = server.URL
+	ctx := context.Background()
+	go notifier.Notify(ctx, tracker, NotificationEvent{Type: EventFailed, Kind: "Deployment", Namespace: "default", Name: "api"})
+
+	// 다른 리소스의 인시던트는 기다리지 않고 전송
+	done := make(chan error, 1)
+	go func() {
+		done <- notifier.Notify(ctx, tracker, NotificationEvent{Type: EventFailed, Kind: "Deployment", Namespace: "default", Name: "web"})
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the trigger of web waited for the trigger of api")
+	}
+}
+
+func TestChatNotifiers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	type request struct {
+		path string
+		body map[string]interface{}
+	}
+	received := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received <- request{path: r.URL.Path, body: body}
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "chat", Namespace: "default"},
+		Data: map[string][]byte{
+			"url":   []byte(server.URL + "/hooks/abc"),
+			"token": []byte("123:ABC"),
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	urlRef := ddukbgv1alpha1.SecretKeyRef{Name: "chat", Key: "url"}
+
+	event := NotificationEvent{
+		Type:      EventReady,
+		Kind:      "Deployment",
+		Namespace: "default",
+		Name:      "test-app",
+		Message:   readyMessage("Deployment", "default", "test-app", 3, 3),
+		Timestamp: time.Now(),
+	}
+
+	tests := []struct {
+		name     string
+		notifier Notifier
+		notify   ddukbgv1alpha1.NotifyConfig
+		path     string
+		check    func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			name:     "Discord",
+			notifier: &DiscordNotifier{Client: c},
+			notify:   ddukbgv1alpha1.NotifyConfig{Discord: &ddukbgv1alpha1.ChatWebhookConfig{URLSecretRef: urlRef}},
+			path:     "/hooks/abc",
+			check: func(t *testing.T, body map[string]interface{}) {
+				embed := body["embeds"].([]interface{})[0].(map[string]interface{})
+				assert.Equal(t, "Deployment default/test-app is now ready", embed["title"])
+				assert.Equal(t, event.Message, embed["description"])
+				assert.EqualValues(t, 0x2EB67D, embed["color"])
+			},
+		},
+		{
+			name:     "Telegram",
+			notifier: &TelegramNotifier{Client: c},
+			notify: ddukbgv1alpha1.NotifyConfig{Telegram: &ddukbgv1alpha1.TelegramConfig{
+				BotTokenSecretRef: ddukbgv1alpha1.SecretKeyRef{Name: "chat", Key: "token"},
+				ChatID:            "@deploys",
+				APIURL:            server.URL,
+			}},
+			path: "/bot123:ABC/sendMessage",
+			check: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "@deploys", body["chat_id"])
+				assert.Equal(t, event.Message, body["text"])
+			},
+		},
+		{
+			name:     "Mattermost",
+			notifier: &MattermostNotifier{Client: c},
+			notify: ddukbgv1alpha1.NotifyConfig{Mattermost: &ddukbgv1alpha1.MattermostConfig{
+				URLSecretRef: urlRef,
+				Channel:      "deploys",
+			}},
+			path: "/hooks/abc",
+			check: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, event.Message, body["text"])
+				assert.Equal(t, "deploys", body["channel"])
+			},
+		},
+		{
+			name:     "Google Chat",
+			notifier: &GoogleChatNotifier{Client: c},
+			notify:   ddukbgv1alpha1.NotifyConfig{GoogleChat: &ddukbgv1alpha1.ChatWebhookConfig{URLSecretRef: urlRef}},
+			path:     "/hooks/abc",
+			check: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, event.Message, body["text"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &ddukbgv1alpha1.ResourceTracker{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+				Spec:       ddukbgv1alpha1.ResourceTrackerSpec{Notify: tt.notify},
+			}
+			require.True(t, tt.notifier.Enabled(tracker))
+			require.NoError(t, tt.notifier.Notify(context.Background(), tracker, event))
+
+			req := <-received
+			assert.Equal(t, tt.path, req.path)
+			tt.check(t, req.body)
+		})
+	}
+}
+
+func TestOpsgenieLifecycle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	type request struct {
+		uri  string
+		auth string
+		body map[string]interface{}
+	}
+	var received []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received = append(received, request{uri: r.URL.RequestURI(), auth: r.Header.Get("Authorization"), body: body})
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "opsgenie", Namespace: "default"},
+		Data:       map[string][]byte{"apiKey": []byte("genie-key")},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				Opsgenie: &ddukbgv1alpha1.OpsgenieConfig{
+					APIKeySecretRef: ddukbgv1alpha1.SecretKeyRef{Name: "opsgenie", Key: "apiKey"},
+					APIURL:          server.URL,
+				},
+			},
+		},
+	}
+	notifier := &OpsgenieNotifier{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()}
+	ctx := context.Background()
+	event := NotificationEvent{Kind: "Deployment", Namespace: "default", Name: "test-app"}
+
+	// 실패한 적 없는 리소스의 Ready 이벤트는 무시
+	event.Type = EventReady
+	require.NoError(t, notifier.Notify(ctx, tracker, event))
+	assert.Empty(t, received)
+
+	event.Type, event.Reason = EventFailed, "CrashLoopBackOff"
+	require.NoError(t, notifier.Notify(ctx, tracker, event))
+	require.Len(t, received, 1)
+	assert.Equal(t, "/v2/alerts", received[0].uri)
+	assert.Equal(t, "GenieKey genie-key", received[0].auth)
+	assert.Equal(t, "Deployment default/test-app has failed", received[0].body["message"])
+	assert.Equal(t, "P3", received[0].body["priority"])
+	alias := received[0].body["alias"].(string)
+	assert.Equal(t, "k8s-deploy-watcher/default/test-tracker/default/test-app", alias)
+
+	tracker.Status.Failures = map[string]string{"default/test-app": "CrashLoopBackOff"}
+	event.Type = EventReady
+	require.NoError(t, notifier.Notify(ctx, tracker, event))
+	require.Len(t, received, 2)
+	assert.Equal(t, "/v2/alerts/k8s-deploy-watcher%2Fdefault%2Ftest-tracker%2Fdefault%2Ftest-app/close?identifierType=alias",
+		received[1].uri)
+	// Ready 전환 없이 실패가 해소되어도 알림을 닫음
+	event.Type = EventRecovered
+	require.NoError(t, notifier.Notify(ctx, tracker, event))
+	require.Len(t, received, 3)
+	assert.Equal(t, received[1].uri, received[2].uri)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "abc", truncate("abcdef", 3))
+	// 멀티바이트 문자를 자르지 않음
+	assert.Equal(t, "배", truncate("배포 완료", 5))
 }
@@ -0,0 +1,153 @@
+// controllers/notifier.go
+
+package controllers
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// Built-in notification channel types
+const (
+	ChannelSlack       = "slack"
+	ChannelSlackBot    = "slack-bot"
+	ChannelTeams       = "teams"
+	ChannelPagerDuty   = "pagerduty"
+	ChannelOpsgenie    = "opsgenie"
+	ChannelDiscord     = "discord"
+	ChannelTelegram    = "telegram"
+	ChannelMattermost  = "mattermost"
+	ChannelGoogleChat  = "googlechat"
+	ChannelEmail       = "email"
+	ChannelWebhook     = "webhook"
+	ChannelCloudEvents = "cloudevents"
+)
+
+// Notifier delivers notification events to one channel type
+type Notifier interface {
+	// Enabled reports whether the tracker has this channel configured
+	Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool
+
+	// Notify delivers the event using the tracker's channel configuration
+	Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error
+}
+
+// NotifierRegistry holds the notifiers keyed by channel type.
+// Every event is fanned out to all registered notifiers enabled on the tracker.
+type NotifierRegistry struct {
+	mu        sync.RWMutex
+	notifiers map[string]Notifier
+	channels  []string
+}
+
+// NewNotifierRegistry creates an empty registry
+func NewNotifierRegistry() *NotifierRegistry {
+	return &NotifierRegistry{notifiers: make(map[string]Notifier)}
+}
+
+// NewDefaultNotifierRegistry creates a registry with the built-in channels.
+// c is used to read the Secrets referenced by the channel configurations
+// and to record delivery state such as Slack threads in the tracker status.
+func NewDefaultNotifierRegistry(c client.Client) *NotifierRegistry {
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, &SlackNotifier{Client: c})
+	registry.Register(ChannelSlackBot, NewSlackBotNotifier(c))
+	registry.Register(ChannelTeams, &TeamsNotifier{Client: c})
+	registry.Register(ChannelPagerDuty, NewPagerDutyNotifier(c))
+	registry.Register(ChannelOpsgenie, &OpsgenieNotifier{Client: c})
+	registry.Register(ChannelDiscord, &DiscordNotifier{Client: c})
+	registry.Register(ChannelTelegram, &TelegramNotifier{Client: c})
+	registry.Register(ChannelMattermost, &MattermostNotifier{Client: c})
+	registry.Register(ChannelGoogleChat, &GoogleChatNotifier{Client: c})
+	registry.Register(ChannelEmail, &EmailNotifier{Client: c})
+	registry.Register(ChannelWebhook, &WebhookNotifier{Client: c})
+	registry.Register(ChannelCloudEvents, &CloudEventsNotifier{Client: c})
+	return registry
+}
+
+// Register adds or replaces the notifier for a channel type
+func (r *NotifierRegistry) Register(channel string, notifier Notifier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.notifiers[channel]; !exists {
+		r.channels = append(r.channels, channel)
+	}
+	r.notifiers[channel] = notifier
+}
+
+// Get returns the notifier registered for a channel type
+func (r *NotifierRegistry) Get(channel string) (Notifier, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notifier, ok := r.notifiers[channel]
+	return notifier, ok
+}
+
+// Channels returns the registered channel types in registration order
+func (r *NotifierRegistry) Channels() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string(nil), r.channels...)
+}
+
+// updateNotifierStatus applies mutate to the status of the latest tracker object.
+// Notifiers use it to record delivery state from the notification workers; the
+// reconciler preserves that state when its own status update conflicts.
+func updateNotifierStatus(ctx context.Context, c client.Client, key types.NamespacedName,
+	mutate func(status *ddukbgv1alpha1.ResourceTrackerStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tracker := &ddukbgv1alpha1.ResourceTracker{}
+		if err := c.Get(ctx, key, tracker); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		mutate(&tracker.Status)
+		return c.Status().Update(ctx, tracker)
+	})
+}
+
+// keyedMutex serializes the deliveries of one key, e.g. one Slack thread, without
+// holding up the deliveries of other keys. The lock of a key is dropped once no
+// delivery holds or waits for it.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock locks the key and returns the function unlocking it
+func (m *keyedMutex) Lock(key string) (unlock func()) {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*keyedLock)
+	}
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		m.locks[key] = lock
+	}
+	lock.refs++
+	m.mu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if lock.refs--; lock.refs == 0 {
+			delete(m.locks, key)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// controllers/opsgenie.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+const (
+	defaultOpsgenieAPIURL   = "https://api.opsgenie.com"
+	defaultOpsgeniePriority = "P3"
+	// Opsgenie field limits
+	opsgenieMessageLimit     = 130
+	opsgenieDescriptionLimit = 15000
+)
+
+// OpsgenieAlert is the body of a create alert request
+type OpsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Priority    string            `json:"priority"`
+	Source      string            `json:"source"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+// OpsgenieClose is the body of a close alert request
+type OpsgenieClose struct {
+	Source string `json:"source"`
+	Note   string `json:"note,omitempty"`
+}
+
+// OpsgenieNotifier creates an Opsgenie alert when a resource fails and closes it
+// when the resource becomes ready again. Alerts are keyed by an alias, so repeated
+// failures of a resource are deduplicated by Opsgenie.
+type OpsgenieNotifier struct {
+	// Client reads the Secret holding the API key
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *OpsgenieNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.Opsgenie != nil
+}
+
+// Notify implements Notifier. Only failure, readiness, recovery and forgotten events are sent.
+func (n *OpsgenieNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	key := fmt.Sprintf("%s/%s", event.Namespace, event.Name)
+	switch event.Type {
+	case EventFailed:
+	case EventReady, EventRecovered, EventForgotten:
+		// 실패로 기록되지 않았던 리소스는 닫을 알림이 없음
+		if _, failed := tracker.Status.Failures[key]; !failed {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	cfg := tracker.Spec.Notify.Opsgenie
+	apiKey, err := secretValue(ctx, n.Client, tracker.Namespace, &cfg.APIKeySecretRef)
+	if err != nil {
+		return err
+	}
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultOpsgenieAPIURL
+	}
+	apiURL = strings.TrimSuffix(apiURL, "/") + "/v2/alerts"
+	headers := map[string]string{"Authorization": "GenieKey " + apiKey}
+	alias := incidentKey(client.ObjectKeyFromObject(tracker), key)
+
+	if event.Type != EventFailed {
+		closeURL := apiURL + "/" + url.PathEscape(alias) + "/close?identifierType=alias"
+		return sendJSON(ctx, ChannelOpsgenie, http.MethodPost, closeURL, headers,
+			OpsgenieClose{Source: "K8s-Deploy-Watcher", Note: eventTitle(event)})
+	}
+
+	priority := cfg.Priority
+	if priority == "" {
+		priority = defaultOpsgeniePriority
+	}
+	alert := OpsgenieAlert{
+		Message:     truncate(eventTitle(event), opsgenieMessageLimit),
+		Alias:       alias,
+		Description: truncate(event.Message, opsgenieDescriptionLimit),
+		Priority:    priority,
+		Source:      "K8s-Deploy-Watcher",
+		Tags:        []string{event.Kind, event.Namespace},
+		Details: map[string]string{
+			"kind":      event.Kind,
+			"namespace": event.Namespace,
+			"name":      event.Name,
+			"reason":    event.Reason,
+			"images":    strings.Join(event.Images, ","),
+		},
+	}
+	return sendJSON(ctx, ChannelOpsgenie, http.MethodPost, apiURL, headers, alert)
+}
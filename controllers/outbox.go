@@ -0,0 +1,590 @@
+// controllers/outbox.go
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	defaultOutboxRetention     = 5 * time.Minute
+	defaultOutboxInterval      = 30 * time.Second
+	defaultOutboxFlushInterval = time.Second
+	defaultOutboxShards        = 4
+	// maxOutboxShardBytes keeps each outbox ConfigMap well below the 1MiB object limit
+	maxOutboxShardBytes = 800 * 1024
+)
+
+// ErrOutboxFull is returned by Add when the entry does not fit in its ConfigMap.
+// The notification is then not delivered, and the Reconcile that detected it fails
+// so that the transition is detected again.
+var ErrOutboxFull = errors.New("notification outbox is full")
+
+var (
+	outboxBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "deploy_watcher_notification_outbox_bytes",
+		Help: "Size of the entries of each notification outbox ConfigMap.",
+	}, []string{"configmap"})
+	outboxRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "deploy_watcher_notification_outbox_rejected_total",
+		Help: "Notifications rejected because the outbox was full.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(outboxBytes, outboxRejected)
+}
+
+// OutboxEntry is one notification persisted in the outbox until it is delivered
+type OutboxEntry struct {
+	// Key is the idempotency key of the notification on one channel
+	Key       string            `json:"key"`
+	Channel   string            `json:"channel"`
+	Tracker   string            `json:"tracker"`
+	Event     NotificationEvent `json:"event"`
+	CreatedAt time.Time         `json:"createdAt"`
+	// CompletedAt is set once the notification was delivered or given up on.
+	// Completed entries are kept for the retention so that the same notification
+	// is not sent again after a restart.
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// HeldUntil is set on notifications held back by quiet hours. They are released
+	// once it passed, in one digest per tracker and channel.
+	HeldUntil *time.Time `json:"heldUntil,omitempty"`
+}
+
+// outboxRecord is an entry with its serialized form
+type outboxRecord struct {
+	entry OutboxEntry
+	raw   string
+	shard int
+	// persisted is true once the entry was written to its ConfigMap
+	persisted bool
+}
+
+// NotificationOutbox persists notifications in ConfigMaps before they are handed
+// to the NotificationQueue, so that notifications detected right before a controller
+// restart are still delivered (at least once) afterwards. The notifications of one
+// Reconcile are written together by Persist before the tracker status is, and a
+// worker writes completions, replays the entries that are not being delivered and
+// prunes completed ones. Entries are spread over several ConfigMaps by key, each
+// capped below the object size limit.
+type NotificationOutbox struct {
+	client        client.Client
+	reader        client.Reader
+	key           types.NamespacedName
+	shards        int
+	retention     time.Duration
+	interval      time.Duration
+	flushInterval time.Duration
+
+	// loadMu serializes loading the ConfigMaps on first use
+	loadMu sync.Mutex
+	// flushMu serializes writing the ConfigMaps
+	flushMu sync.Mutex
+
+	mu         sync.Mutex
+	loaded     bool
+	records    map[string]*outboxRecord
+	shardBytes []int
+	dirty      map[int]bool
+	inFlight   map[string]bool
+
+	// redeliver hands a replayed entry back to the reconciler for delivery
+	redeliver func(ctx context.Context, entry OutboxEntry) error
+	// release hands the held entries of one tracker and channel back to the
+	// reconciler once their quiet hours ended
+	release func(ctx context.Context, entries []OutboxEntry) error
+}
+
+// NewNotificationOutbox creates an outbox stored in shards ConfigMaps named after
+// key: key itself, then key-1, key-2 and so on. reader should bypass the cache so
+// that the outbox is read as last written; retention is how long delivered
+// notifications are remembered.
+func NewNotificationOutbox(c client.Client, reader client.Reader, key types.NamespacedName, retention time.Duration,
+	shards int) *NotificationOutbox {
+	if retention <= 0 {
+		retention = defaultOutboxRetention
+	}
+	if shards <= 0 {
+		shards = defaultOutboxShards
+	}
+	return &NotificationOutbox{
+		client:        c,
+		reader:        reader,
+		key:           key,
+		shards:        shards,
+		retention:     retention,
+		interval:      defaultOutboxInterval,
+		flushInterval: defaultOutboxFlushInterval,
+		records:       make(map[string]*outboxRecord),
+		shardBytes:    make([]int, shards),
+		dirty:         make(map[int]bool),
+		inFlight:      make(map[string]bool),
+	}
+}
+
+// eventID identifies a transition of a resource watched by a tracker. It is stable
+// across reconciles and restarts, so receivers can use it to drop duplicates, but
+// differs when the same transition happens again, e.g. ready after a flap.
+func eventID(tracker types.NamespacedName, event NotificationEvent) string {
+	id := dedupKey(tracker, event)
+	if event.Name == "" {
+		// 리소스가 없는 요약, 다이제스트는 발생 시각으로 구분
+		id += "|" + event.Timestamp.Format(time.RFC3339Nano)
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Add records the entry in memory; it must not be delivered before Persist wrote
+// it. Held entries are left to the worker, which releases them when they are due.
+// It returns false when the notification is already pending or was delivered
+// within the retention, in which case it must not be sent, and ErrOutboxFull when
+// the entry does not fit.
+func (o *NotificationOutbox) Add(ctx context.Context, entry OutboxEntry) (bool, error) {
+	if err := o.load(ctx); err != nil {
+		return false, err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return false, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, exists := o.records[entry.Key]; exists || o.inFlight[entry.Key] {
+		return false, nil
+	}
+
+	record := &outboxRecord{entry: entry, raw: string(data), shard: o.shardOf(entry.Key)}
+	if o.shardBytes[record.shard]+recordSize(record) > maxOutboxShardBytes {
+		o.evictCompleted(record.shard, recordSize(record))
+		if o.shardBytes[record.shard]+recordSize(record) > maxOutboxShardBytes {
+			outboxRejected.Inc()
+			return false, fmt.Errorf("%w: %s has %d bytes", ErrOutboxFull, o.shardName(record.shard),
+				o.shardBytes[record.shard])
+		}
+	}
+
+	// 기록과 동시에 replay가 같은 항목을 다시 보내지 않도록 전송 중으로 표시
+	if entry.HeldUntil == nil {
+		o.inFlight[entry.Key] = true
+	}
+	o.put(record)
+	return true, nil
+}
+
+// Complete marks the entry delivered (or given up on)
+func (o *NotificationOutbox) Complete(ctx context.Context, key string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.inFlight, key)
+
+	record, ok := o.records[key]
+	if !ok {
+		return nil
+	}
+	entry := record.entry
+	now := time.Now()
+	entry.CompletedAt = &now
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	o.put(&outboxRecord{entry: entry, raw: string(data), shard: record.shard, persisted: record.persisted})
+	return nil
+}
+
+// Persist writes the changed shards now, and returns an error unless every entry
+// of keys is in its ConfigMap
+func (o *NotificationOutbox) Persist(ctx context.Context, keys []string) error {
+	err := o.flush(ctx)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, key := range keys {
+		if record, ok := o.records[key]; !ok || !record.persisted {
+			if err == nil {
+				err = fmt.Errorf("notification %s was not persisted", key)
+			}
+			return fmt.Errorf("failed to persist notification outbox: %w", err)
+		}
+	}
+	return nil
+}
+
+// Discard forgets the entries of keys that were not persisted, so that they can
+// be added again, and releases the persisted ones for the worker to replay
+func (o *NotificationOutbox) Discard(keys []string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, key := range keys {
+		if record, ok := o.records[key]; ok && !record.persisted {
+			o.remove(key)
+		}
+		delete(o.inFlight, key)
+	}
+}
+
+// Release marks the entry as no longer being delivered, e.g. when the queue was
+// full, so that the worker replays it
+func (o *NotificationOutbox) Release(key string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.inFlight, key)
+}
+
+// Start persists the recorded entries and replays pending ones until ctx is
+// cancelled. It implements manager.Runnable.
+func (o *NotificationOutbox) Start(ctx context.Context) error {
+	logger := ctrl.Log.WithName("outbox")
+	replayTicker := time.NewTicker(o.interval)
+	defer replayTicker.Stop()
+	flushTicker := time.NewTicker(o.flushInterval)
+	defer flushTicker.Stop()
+
+	o.replay(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			// 종료 전에 남은 기록을 저장
+			if err := o.flush(context.Background()); err != nil {
+				logger.Error(err, "Failed to persist notification outbox")
+			}
+			return nil
+		case <-flushTicker.C:
+			if err := o.flush(ctx); err != nil {
+				logger.Error(err, "Failed to persist notification outbox")
+			}
+		case <-replayTicker.C:
+			o.replay(ctx)
+		}
+	}
+}
+
+// replay redelivers the pending entries that are not in flight, releases the held
+// entries that are due and prunes completed entries older than the retention
+func (o *NotificationOutbox) replay(ctx context.Context) {
+	logger := ctrl.Log.WithName("outbox")
+	if err := o.load(ctx); err != nil {
+		logger.Error(err, "Failed to read notification outbox")
+		return
+	}
+
+	now := time.Now()
+	var pending []OutboxEntry
+	held := make(map[heldGroup][]OutboxEntry)
+	o.mu.Lock()
+	for key, record := range o.records {
+		if completedAt := record.entry.CompletedAt; completedAt != nil {
+			if time.Since(*completedAt) > o.retention {
+				o.remove(key)
+			}
+			continue
+		}
+		if heldUntil := record.entry.HeldUntil; heldUntil != nil {
+			// 저장되기 전이나 조용한 시간이 끝나기 전에는 보내지 않음
+			if o.release == nil || !record.persisted || o.inFlight[key] || now.Before(*heldUntil) {
+				continue
+			}
+			o.inFlight[key] = true
+			group := heldGroup{tracker: record.entry.Tracker, channel: record.entry.Channel, until: heldUntil.UnixNano()}
+			held[group] = append(held[group], record.entry)
+			continue
+		}
+		if o.redeliver == nil || o.inFlight[key] {
+			continue
+		}
+		o.inFlight[key] = true
+		pending = append(pending, record.entry)
+	}
+	o.mu.Unlock()
+
+	for _, entry := range pending {
+		if err := o.redeliver(ctx, entry); err != nil {
+			logger.Error(err, "Failed to redeliver notification", "key", entry.Key, "channel", entry.Channel)
+			o.Release(entry.Key)
+		}
+	}
+	for group, entries := range held {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Event.Timestamp.Before(entries[j].Event.Timestamp) })
+		if err := o.release(ctx, entries); err != nil {
+			logger.Error(err, "Failed to release held notifications", "tracker", group.tracker, "channel", group.channel)
+			for _, entry := range entries {
+				o.Release(entry.Key)
+			}
+			continue
+		}
+		for _, entry := range entries {
+			if err := o.Complete(ctx, entry.Key); err != nil {
+				logger.Error(err, "Failed to complete outbox entry", "key", entry.Key)
+			}
+		}
+	}
+}
+
+// heldGroup collects the held entries released in one digest
+type heldGroup struct {
+	tracker string
+	channel string
+	until   int64
+}
+
+// load reads the outbox ConfigMaps once
+func (o *NotificationOutbox) load(ctx context.Context) error {
+	o.loadMu.Lock()
+	defer o.loadMu.Unlock()
+	o.mu.Lock()
+	loaded := o.loaded
+	o.mu.Unlock()
+	if loaded {
+		return nil
+	}
+
+	logger := ctrl.Log.WithName("outbox")
+	records := make(map[string]*outboxRecord)
+	dirty := make(map[int]bool)
+	for shard := 0; shard < o.shards; shard++ {
+		cm := &corev1.ConfigMap{}
+		err := o.reader.Get(ctx, o.shardKey(shard), cm)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for key, raw := range cm.Data {
+			var entry OutboxEntry
+			if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+				logger.Error(err, "Dropping unreadable outbox entry", "key", key)
+				dirty[shard] = true
+				continue
+			}
+			record := &outboxRecord{entry: entry, raw: raw, shard: o.shardOf(key), persisted: true}
+			if record.shard != shard {
+				// 샤드 수가 바뀌었으면 새 샤드로 옮김
+				dirty[shard], dirty[record.shard] = true, true
+			}
+			records[key] = record
+		}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for key, record := range records {
+		if _, exists := o.records[key]; !exists {
+			o.put(record)
+		}
+	}
+	for shard := range dirty {
+		o.dirty[shard] = true
+	}
+	o.loaded = true
+	return nil
+}
+
+// flush writes the shards changed since the last flush, each in one update
+func (o *NotificationOutbox) flush(ctx context.Context) error {
+	// flush가 동시에 실행되면 이전 내용이 나중에 저장될 수 있으므로 직렬화
+	o.flushMu.Lock()
+	defer o.flushMu.Unlock()
+
+	o.mu.Lock()
+	shards := make(map[int]map[string]string, len(o.dirty))
+	written := make(map[int][]*outboxRecord, len(o.dirty))
+	for shard := range o.dirty {
+		shards[shard] = make(map[string]string)
+	}
+	for key, record := range o.records {
+		if data, ok := shards[record.shard]; ok {
+			data[key] = record.raw
+			written[record.shard] = append(written[record.shard], record)
+		}
+	}
+	o.dirty = make(map[int]bool)
+	o.mu.Unlock()
+
+	var errs []error
+	for shard, data := range shards {
+		err := o.write(ctx, shard, data)
+		o.mu.Lock()
+		if err != nil {
+			errs = append(errs, err)
+			// 다음 flush에서 다시 저장
+			o.dirty[shard] = true
+		} else {
+			for _, record := range written[shard] {
+				record.persisted = true
+			}
+		}
+		o.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// write replaces the entries of the shard's ConfigMap, creating it when missing
+func (o *NotificationOutbox) write(ctx context.Context, shard int, data map[string]string) error {
+	key := o.shardKey(shard)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		err := o.reader.Get(ctx, key, cm)
+		if apierrors.IsNotFound(err) {
+			if len(data) == 0 {
+				return nil
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Data:       data,
+			}
+			err = o.client.Create(ctx, cm)
+			if apierrors.IsAlreadyExists(err) {
+				// 동시에 생성된 경우 다시 읽어서 재시도
+				return apierrors.NewConflict(corev1.Resource("configmaps"), key.Name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		cm.Data = data
+		return o.client.Update(ctx, cm)
+	})
+}
+
+// put stores the record and marks its shard for the next flush. o.mu must be held.
+func (o *NotificationOutbox) put(record *outboxRecord) {
+	o.remove(record.entry.Key)
+	o.records[record.entry.Key] = record
+	o.shardBytes[record.shard] += recordSize(record)
+	o.dirty[record.shard] = true
+	outboxBytes.WithLabelValues(o.shardName(record.shard)).Set(float64(o.shardBytes[record.shard]))
+}
+
+// remove drops the record and marks its shard for the next flush. o.mu must be held.
+func (o *NotificationOutbox) remove(key string) {
+	record, ok := o.records[key]
+	if !ok {
+		return
+	}
+	delete(o.records, key)
+	o.shardBytes[record.shard] -= recordSize(record)
+	o.dirty[record.shard] = true
+	outboxBytes.WithLabelValues(o.shardName(record.shard)).Set(float64(o.shardBytes[record.shard]))
+}
+
+// evictCompleted drops the oldest completed entries of the shard until size more
+// bytes fit. Their notifications may then be sent again after a restart, which
+// at-least-once delivery allows. o.mu must be held.
+func (o *NotificationOutbox) evictCompleted(shard, size int) {
+	var completed []*outboxRecord
+	for _, record := range o.records {
+		if record.shard == shard && record.entry.CompletedAt != nil {
+			completed = append(completed, record)
+		}
+	}
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].entry.CompletedAt.Before(*completed[j].entry.CompletedAt)
+	})
+	for _, record := range completed {
+		if o.shardBytes[shard]+size <= maxOutboxShardBytes {
+			return
+		}
+		o.remove(record.entry.Key)
+	}
+}
+
+// outboxBatch collects the notifications recorded by one Reconcile until they are
+// persisted
+type outboxBatch struct {
+	mu   sync.Mutex
+	keys []string
+	jobs []*notificationJob
+	// undo reverts the bookkeeping of the batch's notifications when they are dropped
+	undo []func()
+	err  error
+}
+
+type outboxBatchKey struct{}
+
+// withOutboxBatch starts collecting the notifications recorded with ctx
+func withOutboxBatch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, outboxBatchKey{}, &outboxBatch{})
+}
+
+// outboxBatchFrom returns the batch of ctx, or nil outside a Reconcile
+func outboxBatchFrom(ctx context.Context) *outboxBatch {
+	batch, _ := ctx.Value(outboxBatchKey{}).(*outboxBatch)
+	return batch
+}
+
+// add collects the entry of key, and the job delivering it unless it is held
+func (b *outboxBatch) add(key string, job *notificationJob) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.keys = append(b.keys, key)
+	if job != nil {
+		b.jobs = append(b.jobs, job)
+	}
+}
+
+func (b *outboxBatch) onFailure(undo func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.undo = append(b.undo, undo)
+}
+
+func (b *outboxBatch) fail(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.err = errors.Join(b.err, err)
+}
+
+// take returns the collected notifications and empties the batch
+func (b *outboxBatch) take() (keys []string, jobs []*notificationJob, undo []func(), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	keys, jobs, undo, err = b.keys, b.jobs, b.undo, b.err
+	b.keys, b.jobs, b.undo, b.err = nil, nil, nil, nil
+	return keys, jobs, undo, err
+}
+
+func recordSize(record *outboxRecord) int {
+	return len(record.entry.Key) + len(record.raw)
+}
+
+func (o *NotificationOutbox) shardOf(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(o.shards))
+}
+
+// shardKey names the shard's ConfigMap; the first shard keeps the configured name
+func (o *NotificationOutbox) shardKey(shard int) types.NamespacedName {
+	if shard == 0 {
+		return o.key
+	}
+	return types.NamespacedName{Namespace: o.key.Namespace, Name: fmt.Sprintf("%s-%d", o.key.Name, shard)}
+}
+
+func (o *NotificationOutbox) shardName(shard int) string {
+	return o.shardKey(shard).String()
+}
@@ -0,0 +1,318 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var outboxKey = types.NamespacedName{Namespace: "watcher-system", Name: "notification-outbox"}
+
+// outboxEntries flushes the outbox and reads back its ConfigMap
+func outboxEntries(t *testing.T, outbox *NotificationOutbox) map[string]OutboxEntry {
+	require.NoError(t, outbox.flush(context.Background()))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, outbox.reader.Get(context.Background(), outboxKey, cm))
+	entries := make(map[string]OutboxEntry, len(cm.Data))
+	for key, raw := range cm.Data {
+		var entry OutboxEntry
+		require.NoError(t, json.Unmarshal([]byte(raw), &entry))
+		entries[key] = entry
+	}
+	return entries
+}
+
+func TestNotificationOutbox(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	outbox := NewNotificationOutbox(c, c, outboxKey, time.Minute, 1)
+
+	ctx := context.Background()
+	entry := OutboxEntry{Key: "abc.slack", Channel: ChannelSlack, Tracker: "default/test-tracker",
+		Event: NotificationEvent{Type: EventReady, Name: "test-app"}, CreatedAt: time.Now()}
+	added, err := outbox.Add(ctx, entry)
+	require.NoError(t, err)
+	assert.True(t, added, "the ConfigMap is created on first use")
+
+	added, err = outbox.Add(ctx, entry)
+	require.NoError(t, err)
+	assert.False(t, added, "pending notifications are not added twice")
+
+	require.NoError(t, outbox.Complete(ctx, entry.Key))
+	entries := outboxEntries(t, outbox)
+	require.NotNil(t, entries[entry.Key].CompletedAt)
+	assert.Equal(t, "test-app", entries[entry.Key].Event.Name)
+
+	added, err = outbox.Add(ctx, entry)
+	require.NoError(t, err)
+	assert.False(t, added, "delivered notifications are remembered for the retention")
+
+	outbox.retention = time.Nanosecond
+	outbox.replay(ctx)
+	assert.Empty(t, outboxEntries(t, outbox), "completed entries are pruned after the retention")
+}
+
+func TestOutboxDelivery(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+	}
+	// 재시작 전에 기록만 되고 전송되지 않은 알림
+	pending := OutboxEntry{Key: "before-restart.slack", Channel: ChannelSlack, Tracker: "default/test-tracker",
+		Event: NotificationEvent{ID: "before-restart", Type: EventReady, Name: "api", Message: "api is now ready"}}
+	orphaned := OutboxEntry{Key: "deleted.slack", Channel: ChannelSlack, Tracker: "default/deleted-tracker",
+		Event: NotificationEvent{ID: "deleted", Type: EventReady, Name: "web"}}
+	data := make(map[string]string)
+	for _, entry := range []OutboxEntry{pending, orphaned} {
+		raw, err := json.Marshal(entry)
+		require.NoError(t, err)
+		data[entry.Key] = string(raw)
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: outboxKey.Name, Namespace: outboxKey.Namespace}, Data: data}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tracker, cm).Build()
+
+	slack := newFakeNotifier()
+	outbox := NewNotificationOutbox(c, c, outboxKey, time.Minute, 1)
+	r := &ResourceTrackerReconciler{Client: c, Notifiers: newFakeRegistry(ChannelSlack, slack), Outbox: outbox}
+	outbox.redeliver = r.redeliver
+
+	ctx := context.Background()
+	outbox.replay(ctx)
+	require.Len(t, slack.events, 1)
+	replayed := <-slack.events
+	assert.Equal(t, "before-restart", replayed.ID)
+	assert.Equal(t, "api is now ready", replayed.Message)
+	entries := outboxEntries(t, outbox)
+	assert.NotNil(t, entries[pending.Key].CompletedAt)
+	assert.NotNil(t, entries[orphaned.Key].CompletedAt, "entries of deleted trackers are dropped")
+
+	event := NotificationEvent{Type: EventReady, Kind: "Deployment", Namespace: "default", Name: "web", Revision: "3"}
+	require.NoError(t, r.sendNotifications(ctx, tracker, event))
+	require.NoError(t, r.sendNotifications(ctx, tracker, event))
+	require.Len(t, slack.events, 1, "the outbox drops the repeated transition")
+	sent := <-slack.events
+	assert.Equal(t, eventID(types.NamespacedName{Namespace: "default", Name: "test-tracker"}, event), sent.ID)
+	assert.NotNil(t, outboxEntries(t, outbox)[sent.ID+"."+ChannelSlack].CompletedAt)
+	// 플랩 후 같은 리비전으로 다시 Ready가 되면 새 전환으로 전송
+	event.PreviousTransition = time.Now().Truncate(time.Second)
+	require.NoError(t, r.sendNotifications(ctx, tracker, event))
+	require.Len(t, slack.events, 1, "a repeated transition is not taken for a duplicate")
+	assert.NotEqual(t, sent.ID, (<-slack.events).ID)
+}
+
+func TestWebhookIdempotencyKey(t *testing.T) {
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{Webhook: &ddukbgv1alpha1.WebhookConfig{URL: "http://example.com"}},
+		},
+	}
+	req, err := webhookRequestFor(context.Background(), nil, tracker, NotificationEvent{ID: "3f2a"})
+	require.NoError(t, err)
+	assert.Equal(t, "3f2a", req.Headers["Idempotency-Key"])
+}
+
+func TestOutboxAddInFlight(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	outbox := NewNotificationOutbox(c, c, outboxKey, time.Minute, 1)
+
+	// replay가 기록 직후의 항목을 가져가도 다시 보내지 않음
+	var replayed []string
+	outbox.redeliver = func(ctx context.Context, entry OutboxEntry) error {
+		replayed = append(replayed, entry.Key)
+		return nil
+	}
+	ctx := context.Background()
+	added, err := outbox.Add(ctx, OutboxEntry{Key: "abc.slack", Channel: ChannelSlack, Tracker: "default/test-tracker"})
+	require.NoError(t, err)
+	require.True(t, added)
+	outbox.replay(ctx)
+	assert.Empty(t, replayed)
+
+	// 전송 중인 항목은 다시 추가되지 않음
+	added, err = outbox.Add(ctx, OutboxEntry{Key: "abc.slack", Channel: ChannelSlack, Tracker: "default/test-tracker"})
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	outbox.Release("abc.slack")
+	outbox.replay(ctx)
+	assert.Equal(t, []string{"abc.slack"}, replayed)
+}
+
+func TestOutboxShards(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	outbox := NewNotificationOutbox(c, c, outboxKey, time.Minute, 3)
+
+	ctx := context.Background()
+	for i := 0; i < 30; i++ {
+		added, err := outbox.Add(ctx, OutboxEntry{Key: fmt.Sprintf("event-%d.slack", i), Channel: ChannelSlack,
+			Tracker: "default/test-tracker"})
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	// 기록은 Persist나 worker가 모아서 저장
+	cms := &corev1.ConfigMapList{}
+	require.NoError(t, c.List(ctx, cms))
+	assert.Empty(t, cms.Items)
+
+	require.NoError(t, outbox.flush(ctx))
+	require.NoError(t, c.List(ctx, cms))
+	require.Len(t, cms.Items, 3)
+	total := 0
+	for _, cm := range cms.Items {
+		assert.Contains(t, []string{"notification-outbox", "notification-outbox-1", "notification-outbox-2"}, cm.Name)
+		total += len(cm.Data)
+	}
+	assert.Equal(t, 30, total)
+
+	// 재시작 후에는 모든 샤드에서 읽음
+	restarted := NewNotificationOutbox(c, c, outboxKey, time.Minute, 3)
+	var replayed []string
+	restarted.redeliver = func(ctx context.Context, entry OutboxEntry) error {
+		replayed = append(replayed, entry.Key)
+		return nil
+	}
+	restarted.replay(ctx)
+	assert.Len(t, replayed, 30)
+}
+
+func TestOutboxFull(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	outbox := NewNotificationOutbox(c, c, outboxKey, time.Minute, 1)
+
+	ctx := context.Background()
+	detail := strings.Repeat("x", 100*1024)
+	entry := func(i int) OutboxEntry {
+		return OutboxEntry{Key: fmt.Sprintf("event-%d.slack", i), Channel: ChannelSlack, Tracker: "default/test-tracker",
+			Event: NotificationEvent{Type: EventFailed, Detail: detail}}
+	}
+	i := 0
+	for ; ; i++ {
+		added, err := outbox.Add(ctx, entry(i))
+		if err != nil {
+			assert.ErrorIs(t, err, ErrOutboxFull)
+			assert.False(t, added)
+			break
+		}
+		require.True(t, added)
+	}
+	assert.Less(t, outbox.shardBytes[0], maxOutboxShardBytes)
+
+	// 전송이 끝난 항목을 밀어내고 자리를 만듦
+	require.NoError(t, outbox.Complete(ctx, "event-0.slack"))
+	added, err := outbox.Add(ctx, entry(i))
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.NotContains(t, outboxEntries(t, outbox), "event-0.slack")
+
+	// 기록하지 못한 알림은 보내지 않음
+	slack := newFakeNotifier()
+	r := &ResourceTrackerReconciler{Client: c, Notifiers: newFakeRegistry(ChannelSlack, slack), Outbox: outbox}
+	tracker := &ddukbgv1alpha1.ResourceTracker{ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"}}
+	err = r.sendNotifications(ctx, tracker, NotificationEvent{Type: EventReady, Kind: "Deployment",
+		Namespace: "default", Name: "web", Detail: detail + detail})
+	assert.ErrorIs(t, err, ErrOutboxFull)
+	assert.Empty(t, slack.events)
+}
+
+func TestReconcilePersistsOutboxBeforeStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "Deployment", Name: "api", Namespace: "default"},
+		},
+	}
+	// ConfigMap 저장이 실패하는 API 서버
+	failWrites := true
+	writeError := func(obj client.Object) error {
+		if _, ok := obj.(*corev1.ConfigMap); ok && failWrites {
+			return errors.New("etcdserver: request timed out")
+		}
+		return nil
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker, teamDeployment("api", "payments")).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if err := writeError(obj); err != nil {
+					return err
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if err := writeError(obj); err != nil {
+					return err
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	slack := newFakeNotifier()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+		Throttle:  NewNotificationThrottle(time.Minute, 0, 0),
+		Outbox:    NewNotificationOutbox(c, c, outboxKey, time.Minute, 1),
+	}
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tracker)}
+
+	// 알림을 저장하지 못하면 보내지도, 상태에 전환을 기록하지도 않음
+	_, err := r.Reconcile(ctx, req)
+	require.Error(t, err)
+	assert.Empty(t, slack.events)
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Empty(t, updated.Status.ResourceStatus)
+
+	// 다음 Reconcile에서 같은 전환을 다시 감지하여 저장한 뒤 전송
+	failWrites = false
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, slack.events, 1)
+	sent := <-slack.events
+	assert.Equal(t, EventReady, sent.Type)
+	assert.Contains(t, outboxEntries(t, r.Outbox), sent.ID+"."+ChannelSlack)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.True(t, updated.Status.ResourceStatus["default/api"])
+}
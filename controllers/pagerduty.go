@@ -0,0 +1,167 @@
+// controllers/pagerduty.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+const (
+	defaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	defaultPagerDutySeverity  = "error"
+	// pagerDutySummaryLimit is the maximum summary length accepted by the Events API
+	pagerDutySummaryLimit = 1024
+)
+
+// PagerDutyEvent is an Events API v2 request
+type PagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *PagerDutyPayload `json:"payload,omitempty"`
+	Client      string            `json:"client,omitempty"`
+	Links       []PagerDutyLink   `json:"links,omitempty"`
+}
+
+// PagerDutyPayload describes the incident of a trigger event
+type PagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails NotificationEvent `json:"custom_details"`
+}
+
+// PagerDutyLink is a link attached to the incident
+type PagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// PagerDutyNotifier triggers a PagerDuty incident when a resource fails and resolves
+// it when the resource becomes ready again, is deleted or is no longer tracked. The open incident of each resource is
+// recorded in the tracker status.
+type PagerDutyNotifier struct {
+	// Client reads the routing key Secret and records incidents in the tracker status
+	Client client.Client
+
+	// EventsURL is the Events API v2 enqueue endpoint
+	EventsURL string
+
+	// locks keep a trigger and the resolve of the same incident from racing,
+	// without holding up the deliveries of other incidents
+	locks keyedMutex
+}
+
+// NewPagerDutyNotifier creates a notifier using the public Events API endpoint
+func NewPagerDutyNotifier(c client.Client) *PagerDutyNotifier {
+	return &PagerDutyNotifier{Client: c, EventsURL: defaultPagerDutyEventsURL}
+}
+
+// Enabled implements Notifier
+func (n *PagerDutyNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.PagerDuty != nil
+}
+
+// Notify implements Notifier. Only failure, readiness, recovery, deletion and
+// forgotten events are sent.
+func (n *PagerDutyNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	switch event.Type {
+	case EventFailed, EventReady, EventRecovered, EventDeleted, EventForgotten:
+	default:
+		return nil
+	}
+
+	trackerKey := client.ObjectKeyFromObject(tracker)
+	key := fmt.Sprintf("%s/%s", event.Namespace, event.Name)
+	dedupKey := incidentKey(trackerKey, key)
+	unlock := n.locks.Lock(dedupKey)
+	defer unlock()
+
+	if event.Type != EventFailed {
+		// 알림 시점의 스냅샷이 아니라 최신 status에서 열린 인시던트를 확인
+		latest := &ddukbgv1alpha1.ResourceTracker{}
+		if err := n.Client.Get(ctx, trackerKey, latest); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if _, open := latest.Status.PagerDutyIncidents[key]; !open {
+			return nil
+		}
+	}
+
+	cfg := tracker.Spec.Notify.PagerDuty
+	routingKey, err := secretValue(ctx, n.Client, tracker.Namespace, &cfg.RoutingKeySecretRef)
+	if err != nil {
+		return err
+	}
+
+	if event.Type != EventFailed {
+		resolve := PagerDutyEvent{RoutingKey: routingKey, EventAction: "resolve", DedupKey: dedupKey}
+		if err := postJSON(ctx, ChannelPagerDuty, n.EventsURL, resolve); err != nil {
+			return err
+		}
+		return n.recordIncident(ctx, trackerKey, key, "")
+	}
+
+	if err := postJSON(ctx, ChannelPagerDuty, n.EventsURL, pagerDutyTrigger(tracker, event, routingKey, dedupKey)); err != nil {
+		return err
+	}
+	return n.recordIncident(ctx, trackerKey, key, dedupKey)
+}
+
+// recordIncident stores the open incident of the resource in the tracker status,
+// or removes it when dedupKey is empty
+func (n *PagerDutyNotifier) recordIncident(ctx context.Context, trackerKey types.NamespacedName, key, dedupKey string) error {
+	return updateNotifierStatus(ctx, n.Client, trackerKey, func(status *ddukbgv1alpha1.ResourceTrackerStatus) {
+		if dedupKey == "" {
+			delete(status.PagerDutyIncidents, key)
+			return
+		}
+		if status.PagerDutyIncidents == nil {
+			status.PagerDutyIncidents = make(map[string]string)
+		}
+		status.PagerDutyIncidents[key] = dedupKey
+	})
+}
+
+// pagerDutyTrigger builds the trigger event of a failure
+func pagerDutyTrigger(tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent, routingKey, dedupKey string) PagerDutyEvent {
+	severity := tracker.Spec.Notify.PagerDuty.Severity
+	if severity == "" {
+		severity = defaultPagerDutySeverity
+	}
+
+	summary := eventTitle(event)
+	if event.Reason != "" {
+		summary += ": " + event.Reason
+	}
+
+	trigger := PagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		DedupKey:    dedupKey,
+		Client:      "K8s-Deploy-Watcher",
+		Payload: &PagerDutyPayload{
+			Summary:       truncate(summary, pagerDutySummaryLimit),
+			Source:        fmt.Sprintf("%s/%s", event.Namespace, event.Name),
+			Severity:      severity,
+			Component:     fmt.Sprintf("%s/%s", event.Kind, event.Name),
+			Group:         event.Namespace,
+			Class:         event.Reason,
+			CustomDetails: event,
+		},
+	}
+	// 잘못된 대시보드 템플릿은 링크만 생략
+	if url, err := dashboardURL(tracker, event); err == nil && url != "" {
+		trigger.Links = []PagerDutyLink{{Href: url, Text: "Dashboard"}}
+	}
+	return trigger
+}
@@ -3,42 +3,83 @@
 package controllers
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types" // types import 추가
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
 )
 
-// SlackMessage 구조체 정의 추가
-type SlackMessage struct {
-	Text string `json:"text"`
-}
-
 // ResourceTrackerReconciler reconciles a ResourceTracker object
 type ResourceTrackerReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// Notifiers are the notification channels every event is fanned out to.
+	// When nil, the built-in channels are used.
+	Notifiers *NotifierRegistry
+
+	// Notifications delivers notifications asynchronously with retries.
+	// When nil, notifications are sent synchronously from Reconcile.
+	Notifications *NotificationQueue
+
+	// Throttle drops duplicate notifications and rate limits every channel of a tracker.
+	// When nil, every notification is sent.
+	Throttle *NotificationThrottle
+
+	// Digests batch the notifications of trackers with a digest window, and hold
+	// back those of quiet hours when there is no Outbox. When nil, a buffer is created on first use.
+	Digests *DigestBuffer
+
+	// APIReader reads objects that are not worth caching, such as the maintenance
+	// ConfigMap, from the API server. When nil, the Client is used.
+	APIReader client.Reader
+
+	// MaintenanceConfigMap holds cluster-wide maintenance windows every tracker
+	// honours like its own quiet hours. When empty, there are none.
+	MaintenanceConfigMap types.NamespacedName
+
+	// Outbox persists notifications until they are delivered, so that they survive
+	// controller restarts. When nil, pending notifications are only kept in memory.
+	Outbox *NotificationOutbox
+
+	// controller and cache register the watches of generic targets' kinds
+	controller controller.Controller
+	cache      cache.Cache
+	watchesMu  sync.Mutex
+	watches    map[schema.GroupVersionKind]bool
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ResourceTrackerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	if r.Outbox != nil {
+		r.Outbox.redeliver = r.redeliver
+		r.Outbox.release = r.releaseHeld
+	}
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&ddukbgv1alpha1.ResourceTracker{}).
 		Watches(
 			&appsv1.Deployment{},
@@ -48,11 +89,41 @@ func (r *ResourceTrackerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&appsv1.StatefulSet{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForResource),
 		).
+		Watches(
+			&appsv1.DaemonSet{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForResource),
+		).
+		Watches(
+			&batchv1.Job{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForResource),
+		).
+		Watches(
+			&batchv1.CronJob{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForResource),
+		).
 		Watches(
 			&corev1.Pod{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForResource),
 		).
-		Complete(r)
+		// 매핑에는 이름과 네임스페이스만 필요하므로 Secret 값은 캐시하지 않음
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findTrackersForSecret),
+			builder.OnlyMetadata,
+		).
+		// 네임스페이스가 생기거나 레이블이 바뀌면 대상 네임스페이스를 다시 계산
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findTrackersForNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		).
+		Build(r)
+	if err != nil {
+		return err
+	}
+	// 임의 종류의 리소스는 처음 추적할 때 watch를 등록
+	r.controller, r.cache = c, mgr.GetCache()
+	return nil
 }
 
 // findObjectsForResource finds ResourceTrackers that monitor the given resource
@@ -63,17 +134,24 @@ func (r *ResourceTrackerReconciler) findObjectsForResource(ctx context.Context,
 		return nil
 	}
 
+	// informer에서 온 typed 객체에는 TypeMeta가 비어 있을 수 있으므로 scheme에서 찾음
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() && r.Scheme != nil {
+		gvk, _ = apiutil.GVKForObject(obj, r.Scheme)
+	}
+
 	var requests []ctrl.Request
+	// Job을 만든 CronJob은 셀렉터가 있는 트래커가 있을 때 한 번만 조회
+	var ownerCronJob *batchv1.CronJob
 	for _, tracker := range trackers.Items {
 		// 리소스 종류 확인
-		if (tracker.Spec.Target.Kind == "Deployment" && obj.GetObjectKind().GroupVersionKind().Kind == "Deployment") ||
-			(tracker.Spec.Target.Kind == "StatefulSet" && obj.GetObjectKind().GroupVersionKind().Kind == "StatefulSet") ||
-			(tracker.Spec.Target.Kind == "Pod" && obj.GetObjectKind().GroupVersionKind().Kind == "Pod") {
+		if targetMatchesKind(tracker.Spec.Target, gvk) {
 
 			// 네임스페이스 확인
-			if tracker.Spec.Target.Namespace == obj.GetNamespace() {
-				// 특정 리소스 이름이 지정되었다면 이름도 확인
-				if tracker.Spec.Target.Name == "" || tracker.Spec.Target.Name == obj.GetName() {
+			if r.targetInNamespace(ctx, tracker.Spec.Target, obj.GetNamespace()) {
+				// 특정 리소스 이름이 지정되었다면 이름도, 아니면 레이블 셀렉터 확인
+				if (tracker.Spec.Target.Name == "" && targetSelects(tracker.Spec.Target, obj.GetLabels())) ||
+					tracker.Spec.Target.Name == obj.GetName() {
 					requests = append(requests, ctrl.Request{
 						NamespacedName: types.NamespacedName{
 							Name:      tracker.Name,
@@ -83,10 +161,53 @@ func (r *ResourceTrackerReconciler) findObjectsForResource(ctx context.Context,
 				}
 			}
 		}
+
+		// CronJob이 만든 Job은 CronJob 트래커로 전달
+		if tracker.Spec.Target.Kind == "CronJob" && gvk.Kind == "Job" &&
+			r.targetInNamespace(ctx, tracker.Spec.Target, obj.GetNamespace()) {
+			owner := metav1.GetControllerOf(obj)
+			if owner == nil || owner.Kind != "CronJob" ||
+				(tracker.Spec.Target.Name != "" && tracker.Spec.Target.Name != owner.Name) {
+				continue
+			}
+			// 셀렉터는 Job이 아니라 Job을 만든 CronJob의 레이블에 적용
+			if tracker.Spec.Target.Name == "" && tracker.Spec.Target.Selector != nil {
+				if ownerCronJob == nil {
+					ownerCronJob = &batchv1.CronJob{}
+					key := types.NamespacedName{Name: owner.Name, Namespace: obj.GetNamespace()}
+					// CronJob이 삭제되었으면 레이블이 없으므로 셀렉터가 있는 트래커와 일치하지 않음
+					if err := r.Get(ctx, key, ownerCronJob); err != nil && !apierrors.IsNotFound(err) {
+						log.FromContext(ctx).Error(err, "Failed to get the CronJob of a Job", "cronjob", key)
+					}
+				}
+				if !targetSelects(tracker.Spec.Target, ownerCronJob.Labels) {
+					continue
+				}
+			}
+			requests = append(requests, ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      tracker.Name,
+					Namespace: tracker.Namespace,
+				},
+			})
+		}
 	}
 	return requests
 }
 
+// targetMatchesKind reports whether the target tracks resources of the kind.
+// Targets without an apiVersion match built-in kinds by kind alone.
+func targetMatchesKind(target ddukbgv1alpha1.ResourceTarget, gvk schema.GroupVersionKind) bool {
+	if target.Kind != gvk.Kind {
+		return false
+	}
+	apiVersion := target.APIVersion
+	if apiVersion == "" {
+		apiVersion = builtinKinds[target.Kind]
+	}
+	return apiVersion == gvk.GroupVersion().String()
+}
+
 // Reconcile 함수 수정
 func (r *ResourceTrackerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -100,21 +221,53 @@ func (r *ResourceTrackerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if tracker.Status.ResourceStatus == nil {
 		tracker.Status.ResourceStatus = make(map[string]bool)
 	}
+	// 감지한 알림은 outbox에 저장된 뒤에 전송
+	if r.Outbox != nil {
+		ctx = withOutboxBatch(ctx)
+	}
+
+	// 잘못된 셀렉터로 전체 리소스를 추적하지 않도록 먼저 확인
+	if err := validateTarget(tracker.Spec.Target); err != nil {
+		// 스펙이 고쳐지기 전에는 재시도해도 소용없으므로 상태와 이벤트로만 알림
+		message := fmt.Sprintf("Invalid target: %v", err)
+		if tracker.Status.Message == message && !tracker.Status.Ready {
+			return ctrl.Result{}, nil
+		}
+		logger.Info("Ignoring tracker with an invalid target", "error", err.Error())
+		r.Recorder.Event(tracker, corev1.EventTypeWarning, "InvalidTarget", err.Error())
+		tracker.Status.Ready = false
+		tracker.Status.Message = message
+		return ctrl.Result{}, r.updateStatus(ctx, tracker)
+	}
 
 	var result ctrl.Result
 	var err error
 
-	switch tracker.Spec.Target.Kind {
-	case "Deployment":
+	switch kind := tracker.Spec.Target.Kind; {
+	case genericTarget(tracker.Spec.Target):
+		result, err = r.reconcileGeneric(ctx, tracker)
+	case kind == "Deployment":
 		result, err = r.reconcileDeployment(ctx, tracker)
-	case "StatefulSet":
+	case kind == "StatefulSet":
 		result, err = r.reconcileStatefulSet(ctx, tracker)
-	case "Pod":
+	case kind == "DaemonSet":
+		result, err = r.reconcileDaemonSet(ctx, tracker)
+	case kind == "Job":
+		result, err = r.reconcileJob(ctx, tracker)
+	case kind == "CronJob":
+		result, err = r.reconcileCronJob(ctx, tracker)
+	case kind == "Pod":
 		result, err = r.reconcilePod(ctx, tracker)
+	case kind == "Rollout":
+		result, err = r.reconcileRollout(ctx, tracker)
 	default:
-		return ctrl.Result{}, fmt.Errorf("unsupported resource kind: %s", tracker.Spec.Target.Kind)
+		return ctrl.Result{}, fmt.Errorf("unsupported resource kind: %s; set apiVersion to track other kinds", kind)
 	}
 
+	// 상태가 바뀌지 않아 아직 저장되지 않은 알림을 저장하고 전송
+	if persistErr := r.persistOutbox(ctx); persistErr != nil && err == nil {
+		err = persistErr
+	}
 	if err != nil {
 		logger.Error(err, "Failed to reconcile resource")
 		return ctrl.Result{}, err
@@ -123,78 +276,95 @@ func (r *ResourceTrackerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	return result, nil
 }
 
-// sendSlackNotification sends a notification to Slack
-var sendSlackNotification = func(webhookURL string, message string) error {
-	payload := SlackMessage{
-		Text: message,
-	}
-
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal slack message: %v", err)
+// updateStatus writes the tracker status. Notifiers record delivery state such as
+// Slack threads and PagerDuty incidents in the status concurrently, so on a conflict
+// that state is taken from the latest object and the update is retried.
+func (r *ResourceTrackerReconciler) updateStatus(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) error {
+	// 전환을 기록하기 전에 그 알림을 저장해야 재시작해도 잃어버리지 않음
+	if err := r.persistOutbox(ctx); err != nil {
+		return err
 	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Update(ctx, tracker)
+		if !apierrors.IsConflict(err) {
+			return err
+		}
 
-	resp, err := http.Post(webhookURL, "application/json", bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("failed to send slack notification: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("slack notification failed with status code: %d", resp.StatusCode)
-	}
+		latest := &ddukbgv1alpha1.ResourceTracker{}
+		if getErr := r.Get(ctx, client.ObjectKeyFromObject(tracker), latest); getErr != nil {
+			return getErr
+		}
+		tracker.ResourceVersion = latest.ResourceVersion
+		tracker.Status.SlackThreads = latest.Status.SlackThreads
+		tracker.Status.PagerDutyIncidents = latest.Status.PagerDutyIncidents
+		return err
+	})
+}
 
-	return nil
+// trackedResource is what the reconcile function of a built-in kind reads from one resource
+type trackedResource struct {
+	ready bool
+	event NotificationEvent
+	// failure reports why the resource failed, or "" if it has not, given when it
+	// stopped being ready. It is called once the transition is recorded.
+	failure func(notReadySince metav1.Time) (reason, detail string)
+	// message is written to the status when the resource is tracked by name
+	message string
 }
 
-// reconcileDeployment handles Deployment type resources
-func (r *ResourceTrackerReconciler) reconcileDeployment(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
+// resourceKind reads the resources of a built-in kind
+type resourceKind struct {
+	newObject func() client.Object
+	newList   func() client.ObjectList
+	observe   func(obj client.Object) trackedResource
+	// summary is written to the status when several resources are tracked
+	summary func(ready, total int) string
+	// notFound is written to the status when the named resource does not exist
+	notFound string
+}
 
-	// 네임스페이스 전체 모니터링인 경우
-	if tracker.Spec.Target.Name == "" {
-		deployList := &appsv1.DeploymentList{}
-		if err := r.List(ctx, deployList, client.InNamespace(tracker.Spec.Target.Namespace)); err != nil {
+// reconcileResources tracks the named resource or every resource the target
+// selects, observing each of them with observeResource
+func (r *ResourceTrackerReconciler) reconcileResources(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	kind resourceKind) (ctrl.Result, error) {
+	// 네임스페이스 전체 또는 여러 네임스페이스 모니터링인 경우
+	if !singleResource(tracker.Spec.Target) {
+		list := kind.newList()
+		if err := r.listTargets(ctx, tracker.Spec.Target, list); err != nil {
+			return ctrl.Result{}, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
 			return ctrl.Result{}, err
 		}
 
 		statusChanged := false
-		readyDeployments := 0
-		totalDeployments := len(deployList.Items)
-
-		for _, deploy := range deployList.Items {
-			key := fmt.Sprintf("%s/%s", deploy.Namespace, deploy.Name)
-			isReady := deploy.Status.ReadyReplicas == *deploy.Spec.Replicas &&
-				deploy.Status.UpdatedReplicas == *deploy.Spec.Replicas &&
-				deploy.Status.AvailableReplicas == *deploy.Spec.Replicas
-
-			if isReady {
-				readyDeployments++
+		readyResources := 0
+		present := make(map[string]bool, len(items))
+		for _, item := range items {
+			obj := item.(client.Object)
+			key := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+			present[key] = true
+
+			resource := kind.observe(obj)
+			if resource.ready {
+				readyResources++
 			}
-
-			if tracker.Status.ResourceStatus[key] != isReady {
+			if r.observeResource(ctx, tracker, key, resource) {
 				statusChanged = true
-				tracker.Status.ResourceStatus[key] = isReady
-
-				if isReady {
-					r.Recorder.Event(tracker, corev1.EventTypeNormal, "DeploymentReady",
-						fmt.Sprintf("Deployment %s is ready", key))
-
-					if tracker.Spec.Notify.Slack != "" {
-						message := formatSlackMessage("Deployment", deploy.Namespace, deploy.Name,
-							deploy.Status.ReadyReplicas, *deploy.Spec.Replicas)
-						if err := sendSlackNotification(tracker.Spec.Notify.Slack, message); err != nil {
-							logger.Error(err, "Failed to send Slack notification")
-						}
-					}
-				}
 			}
 		}
+		if r.forgetDeletedResources(ctx, tracker, present) {
+			statusChanged = true
+		}
 
 		if statusChanged {
-			tracker.Status.CurrentState.ReadyReplicas = int32(readyDeployments)
-			tracker.Status.CurrentState.TotalReplicas = int32(totalDeployments)
-			if err := r.Status().Update(ctx, tracker); err != nil {
+			tracker.Status.CurrentState.ReadyReplicas = int32(readyResources)
+			tracker.Status.CurrentState.TotalReplicas = int32(len(items))
+			if kind.summary != nil {
+				tracker.Status.Message = kind.summary(readyResources, len(items))
+			}
+			if err := r.updateStatus(ctx, tracker); err != nil {
 				return ctrl.Result{}, err
 			}
 		}
@@ -202,131 +372,39 @@ func (r *ResourceTrackerReconciler) reconcileDeployment(ctx context.Context, tra
 		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
 	}
 
-	// 단일 Deployment 모니터링 로직
-	deploy := &appsv1.Deployment{}
+	// 단일 리소스 모니터링 로직
+	obj := kind.newObject()
 	if err := r.Get(ctx, types.NamespacedName{
 		Name:      tracker.Spec.Target.Name,
 		Namespace: tracker.Spec.Target.Namespace,
-	}, deploy); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
-	}
-
-	isReady := deploy.Status.ReadyReplicas == *deploy.Spec.Replicas &&
-		deploy.Status.UpdatedReplicas == *deploy.Spec.Replicas &&
-		deploy.Status.AvailableReplicas == *deploy.Spec.Replicas
-
-	key := fmt.Sprintf("%s/%s", deploy.Namespace, deploy.Name)
-	if tracker.Status.ResourceStatus[key] != isReady {
-		tracker.Status.ResourceStatus[key] = isReady
-		tracker.Status.CurrentState.ReadyReplicas = deploy.Status.ReadyReplicas
-		tracker.Status.CurrentState.TotalReplicas = *deploy.Spec.Replicas
-
-		if isReady {
-			r.Recorder.Event(tracker, corev1.EventTypeNormal, "DeploymentReady",
-				fmt.Sprintf("Deployment %s is ready", key))
-
-			if tracker.Spec.Notify.Slack != "" {
-				message := formatSlackMessage("Deployment", deploy.Namespace, deploy.Name,
-					deploy.Status.ReadyReplicas, *deploy.Spec.Replicas)
-				if err := sendSlackNotification(tracker.Spec.Notify.Slack, message); err != nil {
-					logger.Error(err, "Failed to send Slack notification")
-				}
-			}
-		}
-
-		if err := r.Status().Update(ctx, tracker); err != nil {
+	}, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
-	}
-
-	return ctrl.Result{RequeueAfter: time.Second * 30}, nil
-}
-
-func (r *ResourceTrackerReconciler) reconcileStatefulSet(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
-
-	// 네임스페이스 전체 모니터링인 경우
-	if tracker.Spec.Target.Name == "" {
-		stsList := &appsv1.StatefulSetList{}
-		if err := r.List(ctx, stsList, client.InNamespace(tracker.Spec.Target.Namespace)); err != nil {
-			return ctrl.Result{}, err
+		// 추적하던 리소스가 삭제되었으면 삭제 알림 후 상태 정리
+		statusChanged := r.forgetDeletedResources(ctx, tracker, nil)
+		if kind.notFound != "" && tracker.Status.Message != kind.notFound {
+			tracker.Status.Message = kind.notFound
+			statusChanged = true
 		}
-
-		statusChanged := false
-		readySts := 0
-		totalSts := len(stsList.Items)
-
-		for _, sts := range stsList.Items {
-			key := fmt.Sprintf("%s/%s", sts.Namespace, sts.Name)
-			isReady := sts.Status.ReadyReplicas == *sts.Spec.Replicas &&
-				sts.Status.UpdatedReplicas == *sts.Spec.Replicas
-
-			if isReady {
-				readySts++
-			}
-
-			if tracker.Status.ResourceStatus[key] != isReady {
-				statusChanged = true
-				tracker.Status.ResourceStatus[key] = isReady
-
-				if isReady {
-					r.Recorder.Event(tracker, corev1.EventTypeNormal, "StatefulSetReady",
-						fmt.Sprintf("StatefulSet %s is ready", key))
-
-					if tracker.Spec.Notify.Slack != "" {
-						message := formatSlackMessage("StatefulSet", sts.Namespace, sts.Name,
-							sts.Status.ReadyReplicas, *sts.Spec.Replicas)
-						if err := sendSlackNotification(tracker.Spec.Notify.Slack, message); err != nil {
-							logger.Error(err, "Failed to send Slack notification")
-						}
-					}
-				}
-			}
-		}
-
 		if statusChanged {
-			tracker.Status.CurrentState.ReadyReplicas = int32(readySts)
-			tracker.Status.CurrentState.TotalReplicas = int32(totalSts)
-			if err := r.Status().Update(ctx, tracker); err != nil {
-				return ctrl.Result{}, err
-			}
+			return ctrl.Result{}, r.updateStatus(ctx, tracker)
 		}
-
-		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+		return ctrl.Result{}, nil
 	}
 
-	// 단일 StatefulSet 모니터링 로직
-	sts := &appsv1.StatefulSet{}
-	if err := r.Get(ctx, types.NamespacedName{
-		Name:      tracker.Spec.Target.Name,
-		Namespace: tracker.Spec.Target.Namespace,
-	}, sts); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+	key := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+	resource := kind.observe(obj)
+	statusChanged := r.observeResource(ctx, tracker, key, resource)
+	if resource.message != "" && tracker.Status.Message != resource.message {
+		tracker.Status.Message = resource.message
+		statusChanged = true
 	}
 
-	isReady := sts.Status.ReadyReplicas == *sts.Spec.Replicas &&
-		sts.Status.UpdatedReplicas == *sts.Spec.Replicas
-
-	key := fmt.Sprintf("%s/%s", sts.Namespace, sts.Name)
-	if tracker.Status.ResourceStatus[key] != isReady {
-		tracker.Status.ResourceStatus[key] = isReady
-		tracker.Status.CurrentState.ReadyReplicas = sts.Status.ReadyReplicas
-		tracker.Status.CurrentState.TotalReplicas = *sts.Spec.Replicas
-
-		if isReady {
-			r.Recorder.Event(tracker, corev1.EventTypeNormal, "StatefulSetReady",
-				fmt.Sprintf("StatefulSet %s is ready", key))
-
-			if tracker.Spec.Notify.Slack != "" {
-				message := formatSlackMessage("StatefulSet", sts.Namespace, sts.Name,
-					sts.Status.ReadyReplicas, *sts.Spec.Replicas)
-				if err := sendSlackNotification(tracker.Spec.Notify.Slack, message); err != nil {
-					logger.Error(err, "Failed to send Slack notification")
-				}
-			}
-		}
-
-		if err := r.Status().Update(ctx, tracker); err != nil {
+	if statusChanged {
+		tracker.Status.CurrentState.ReadyReplicas = resource.event.ReadyReplicas
+		tracker.Status.CurrentState.TotalReplicas = resource.event.TotalReplicas
+		if err := r.updateStatus(ctx, tracker); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
@@ -334,165 +412,139 @@ func (r *ResourceTrackerReconciler) reconcileStatefulSet(ctx context.Context, tr
 	return ctrl.Result{RequeueAfter: time.Second * 30}, nil
 }
 
-// detectImageChange checks if the deployment's image has changed
-func (r *ResourceTrackerReconciler) detectImageChange(deployment *appsv1.Deployment) (bool, string, string) {
-	if len(deployment.Spec.Template.Spec.Containers) == 0 {
-		return false, "", ""
-	}
-
-	currentImage := deployment.Spec.Template.Spec.Containers[0].Image
-	annotations := deployment.Annotations
-	if annotations == nil {
-		annotations = make(map[string]string)
-		deployment.Annotations = annotations
-	}
-
-	previousImage := annotations["ddukbg.k8s/previous-image"]
-	if previousImage == "" {
-		// 최초 실행시
-		deployment.Annotations["ddukbg.k8s/previous-image"] = currentImage
-		if err := r.Update(context.Background(), deployment); err != nil {
-			return false, "", ""
+// observeResource records the state of one tracked resource and notifies its image
+// changes, scaling, ready and not-ready transitions and failures. It returns true
+// if the tracker status was modified.
+func (r *ResourceTrackerReconciler) observeResource(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	key string, resource trackedResource) bool {
+	event := resource.event
+	change := recordResourceState(tracker, key, resource.ready, &event)
+	statusChanged := change.statusChanged
+	r.notifyChanges(ctx, tracker, event, change)
+
+	if tracker.Status.ResourceStatus[key] != resource.ready {
+		statusChanged = true
+		tracker.Status.ResourceStatus[key] = resource.ready
+
+		if resource.ready {
+			r.Recorder.Event(tracker, corev1.EventTypeNormal, event.Kind+"Ready",
+				fmt.Sprintf("%s %s is ready", event.Kind, key))
+			event.Type = EventReady
+		} else {
+			event.Type = EventNotReady
 		}
-		return false, currentImage, currentImage
-	}
-
-	if previousImage != currentImage {
-		deployment.Annotations["ddukbg.k8s/previous-image"] = currentImage
-		if err := r.Update(context.Background(), deployment); err != nil {
-			return false, "", ""
+		if err := r.sendNotifications(ctx, tracker, event); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to send notification")
 		}
-		return true, previousImage, currentImage
 	}
 
-	return false, currentImage, currentImage
-}
-
-// reconcilePod handles Pod type resources
-func (r *ResourceTrackerReconciler) reconcilePod(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
-
-	// 상태 맵 초기화
-	if tracker.Status.ResourceStatus == nil {
-		tracker.Status.ResourceStatus = make(map[string]bool)
+	reason, detail := resource.failure(tracker.Status.TransitionTimes[key])
+	if r.updateFailureStatus(ctx, tracker, event, reason, detail) {
+		statusChanged = true
 	}
+	return statusChanged
+}
 
-	// 네임스페이스 전체 모니터링인 경우
-	if tracker.Spec.Target.Name == "" {
-		podList := &corev1.PodList{}
-		if err := r.List(ctx, podList, client.InNamespace(tracker.Spec.Target.Namespace)); err != nil {
-			logger.Error(err, "Failed to list Pods")
-			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
-		}
-
-		statusChanged := false
-		readyPods := 0
-		totalPods := len(podList.Items)
-
-		// 각 Pod 개별 처리
-		for _, pod := range podList.Items {
-			key := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
-			isReady := pod.Status.Phase == corev1.PodRunning
-
-			if isReady {
-				readyPods++
+// reconcileDeployment handles Deployment type resources
+func (r *ResourceTrackerReconciler) reconcileDeployment(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
+	return r.reconcileResources(ctx, tracker, resourceKind{
+		newObject: func() client.Object { return &appsv1.Deployment{} },
+		newList:   func() client.ObjectList { return &appsv1.DeploymentList{} },
+		observe: func(obj client.Object) trackedResource {
+			deploy := obj.(*appsv1.Deployment)
+			isReady := deploy.Status.ReadyReplicas == *deploy.Spec.Replicas &&
+				deploy.Status.UpdatedReplicas == *deploy.Spec.Replicas &&
+				deploy.Status.AvailableReplicas == *deploy.Spec.Replicas
+			return trackedResource{
+				ready: isReady,
+				event: deploymentEvent(deploy, isReady),
+				failure: func(metav1.Time) (string, string) {
+					return deploymentFailure(deploy)
+				},
 			}
+		},
+	})
+}
 
-			if tracker.Status.ResourceStatus[key] != isReady {
-				statusChanged = true
-				tracker.Status.ResourceStatus[key] = isReady
-
-				if isReady {
-					r.Recorder.Event(tracker, corev1.EventTypeNormal, "PodReady",
-						fmt.Sprintf("Pod %s is running successfully", pod.Name))
-
-					if tracker.Spec.Notify.Slack != "" {
-						message := fmt.Sprintf("Pod %s/%s is now ready\n"+
-							"> Namespace: %s\n"+
-							"> Status: Running\n"+
-							"> Phase: %s",
-							pod.Namespace, pod.Name,
-							pod.Namespace,
-							pod.Status.Phase)
-						if err := sendSlackNotification(tracker.Spec.Notify.Slack, message); err != nil {
-							logger.Error(err, "Failed to send Slack notification")
-						}
-					}
-				}
+// reconcileStatefulSet handles StatefulSet type resources
+func (r *ResourceTrackerReconciler) reconcileStatefulSet(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
+	return r.reconcileResources(ctx, tracker, resourceKind{
+		newObject: func() client.Object { return &appsv1.StatefulSet{} },
+		newList:   func() client.ObjectList { return &appsv1.StatefulSetList{} },
+		observe: func(obj client.Object) trackedResource {
+			sts := obj.(*appsv1.StatefulSet)
+			isReady := sts.Status.ReadyReplicas == *sts.Spec.Replicas &&
+				sts.Status.UpdatedReplicas == *sts.Spec.Replicas
+			return trackedResource{
+				ready: isReady,
+				event: statefulSetEvent(sts, isReady),
+				failure: func(notReadySince metav1.Time) (string, string) {
+					return statefulSetFailure(sts, isReady, notReadySince)
+				},
 			}
-		}
+		},
+	})
+}
 
-		// 전체 상태 업데이트
-		if statusChanged {
-			tracker.Status.CurrentState.ReadyReplicas = int32(readyPods)
-			tracker.Status.CurrentState.TotalReplicas = int32(totalPods)
-			tracker.Status.Message = fmt.Sprintf("%d/%d pods are running", readyPods, totalPods)
+// daemonSetReady reports whether the DaemonSet controller has observed the latest
+// spec and every scheduled pod is updated, ready and available
+func daemonSetReady(ds *appsv1.DaemonSet) bool {
+	return ds.Status.ObservedGeneration >= ds.Generation &&
+		ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled &&
+		ds.Status.NumberReady == ds.Status.DesiredNumberScheduled &&
+		ds.Status.NumberUnavailable == 0
+}
 
-			if err := r.Status().Update(ctx, tracker); err != nil {
-				logger.Error(err, "Failed to update ResourceTracker status")
-				return ctrl.Result{}, err
+// reconcileDaemonSet handles DaemonSet type resources
+func (r *ResourceTrackerReconciler) reconcileDaemonSet(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
+	return r.reconcileResources(ctx, tracker, resourceKind{
+		newObject: func() client.Object { return &appsv1.DaemonSet{} },
+		newList:   func() client.ObjectList { return &appsv1.DaemonSetList{} },
+		observe: func(obj client.Object) trackedResource {
+			ds := obj.(*appsv1.DaemonSet)
+			isReady := daemonSetReady(ds)
+			return trackedResource{
+				ready: isReady,
+				event: daemonSetEvent(ds, isReady),
+				failure: func(notReadySince metav1.Time) (string, string) {
+					return daemonSetFailure(ds, isReady, notReadySince)
+				},
 			}
-		}
-
-		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
-	}
+		},
+	})
+}
 
-	// 단일 Pod 모니터링인 경우
-	pod := &corev1.Pod{}
-	if err := r.Get(ctx, types.NamespacedName{
-		Name:      tracker.Spec.Target.Name,
-		Namespace: tracker.Spec.Target.Namespace,
-	}, pod); err != nil {
-		if apierrors.IsNotFound(err) {
-			tracker.Status.Message = "Pod not found"
-			if err := r.Status().Update(ctx, tracker); err != nil {
-				logger.Error(err, "Failed to update ResourceTracker status")
-				return ctrl.Result{}, err
+// reconcilePod handles Pod type resources
+func (r *ResourceTrackerReconciler) reconcilePod(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
+	return r.reconcileResources(ctx, tracker, resourceKind{
+		newObject: func() client.Object { return &corev1.Pod{} },
+		newList:   func() client.ObjectList { return &corev1.PodList{} },
+		observe: func(obj client.Object) trackedResource {
+			pod := obj.(*corev1.Pod)
+			isReady := pod.Status.Phase == corev1.PodRunning
+			reason, detail := podFailure(pod)
+
+			message := fmt.Sprintf("Pod is not ready: %s", pod.Status.Phase)
+			switch {
+			case reason != "":
+				message = fmt.Sprintf("Pod has failed: %s", reason)
+			case isReady:
+				message = "Pod is running successfully"
 			}
-			return ctrl.Result{RequeueAfter: time.Second * 30}, nil
-		}
-		logger.Error(err, "Failed to get Pod")
-		return ctrl.Result{RequeueAfter: time.Second * 10}, err
-	}
-
-	// Pod 상태 확인
-	key := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
-	isReady := pod.Status.Phase == corev1.PodRunning
-	statusChanged := tracker.Status.ResourceStatus[key] != isReady
-
-	if statusChanged {
-		tracker.Status.ResourceStatus[key] = isReady
-		tracker.Status.CurrentState.ReadyReplicas = boolToInt32(isReady)
-		tracker.Status.CurrentState.TotalReplicas = 1
-
-		if isReady {
-			tracker.Status.Message = "Pod is running successfully"
-			r.Recorder.Event(tracker, corev1.EventTypeNormal, "PodReady",
-				fmt.Sprintf("Pod %s is running successfully", pod.Name))
-
-			if tracker.Spec.Notify.Slack != "" {
-				message := fmt.Sprintf("Pod %s/%s is now ready\n"+
-					"> Namespace: %s\n"+
-					"> Status: Running\n"+
-					"> Phase: %s",
-					pod.Namespace, pod.Name,
-					pod.Namespace,
-					pod.Status.Phase)
-				if err := sendSlackNotification(tracker.Spec.Notify.Slack, message); err != nil {
-					logger.Error(err, "Failed to send Slack notification")
-				}
+			return trackedResource{
+				ready: isReady,
+				event: podEvent(pod, isReady),
+				failure: func(metav1.Time) (string, string) {
+					return reason, detail
+				},
+				message: message,
 			}
-		} else {
-			tracker.Status.Message = fmt.Sprintf("Pod is not ready: %s", pod.Status.Phase)
-		}
-
-		if err := r.Status().Update(ctx, tracker); err != nil {
-			logger.Error(err, "Failed to update ResourceTracker status")
-			return ctrl.Result{}, err
-		}
-	}
-
-	return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+		},
+		summary: func(ready, total int) string {
+			return fmt.Sprintf("%d/%d pods are running", ready, total)
+		},
+		notFound: "Pod not found",
+	})
 }
 
 // bool을 int32로 변환하는 헬퍼 함수
@@ -502,50 +554,3 @@ func boolToInt32(b bool) int32 {
 	}
 	return 0
 }
-
-func (r *ResourceTrackerReconciler) checkDeploymentStatus(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, deploy *appsv1.Deployment) (bool, bool) {
-	isReady := deploy.Status.ReadyReplicas == *deploy.Spec.Replicas &&
-		deploy.Status.UpdatedReplicas == *deploy.Spec.Replicas &&
-		deploy.Status.AvailableReplicas == *deploy.Spec.Replicas
-
-	key := fmt.Sprintf("%s/%s", deploy.Namespace, deploy.Name)
-	statusChanged := tracker.Status.ResourceStatus[key] != isReady
-
-	if statusChanged {
-		if tracker.Status.ResourceStatus == nil {
-			tracker.Status.ResourceStatus = make(map[string]bool)
-		}
-		tracker.Status.ResourceStatus[key] = isReady
-
-		if isReady {
-			r.Recorder.Event(tracker, corev1.EventTypeNormal, "ResourceReady",
-				fmt.Sprintf("Deployment %s is running successfully", deploy.Name))
-
-			if tracker.Spec.Notify.Slack != "" {
-				message := fmt.Sprintf("*Deployment %s/%s is now ready*\n"+
-					"> Namespace: %s\n"+
-					"> Status: Running\n"+
-					"> Replicas: %d/%d ready",
-					deploy.Namespace, deploy.Name,
-					deploy.Namespace,
-					deploy.Status.ReadyReplicas, *deploy.Spec.Replicas)
-				if err := sendSlackNotification(tracker.Spec.Notify.Slack, message); err != nil {
-					return isReady, false
-				}
-			}
-		}
-	}
-
-	return isReady, statusChanged
-}
-
-// Slack 메시지 포맷 함수
-func formatSlackMessage(kind, namespace, name string, readyReplicas, totalReplicas int32) string {
-	return fmt.Sprintf("%s %s/%s is now ready\n"+
-		"> Namespace: %s\n"+
-		"> Status: Running\n"+
-		"> Replicas: %d/%d ready",
-		kind, namespace, name,
-		namespace,
-		readyReplicas, totalReplicas)
-}
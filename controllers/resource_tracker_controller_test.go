@@ -24,15 +24,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-var receivedMessages = make(chan string, 10)
-
-func init() {
-	sendSlackNotification = func(webhookURL string, message string) error {
-		receivedMessages <- message
-		return nil
-	}
-}
-
 func TestReconcileResourceTracker(t *testing.T) {
 	// 전체 테스트 타임아웃 설정 제거
 	// testTimeout := time.After(time.Second * 10) // 이 줄 제거
@@ -93,6 +84,26 @@ func TestReconcileResourceTracker(t *testing.T) {
 			expectReady:   false,
 			expectError:   false,
 		},
+		{
+			name:          "정상적인 DaemonSet 추적",
+			kind:          "DaemonSet",
+			resourceName:  "test-fluent-bit",
+			replicas:      4,
+			readyReplicas: 4,
+			initialImage:  "fluent/fluent-bit:3.0",
+			expectReady:   true,
+			expectError:   false,
+		},
+		{
+			name:          "DaemonSet 배포 진행 중",
+			kind:          "DaemonSet",
+			resourceName:  "test-fluent-bit",
+			replicas:      4,
+			readyReplicas: 2,
+			initialImage:  "fluent/fluent-bit:3.0",
+			expectReady:   false,
+			expectError:   false,
+		},
 		{
 			name:          "지원하지 않는 리소스 종류",
 			kind:          "InvalidKind",
@@ -105,14 +116,7 @@ func TestReconcileResourceTracker(t *testing.T) {
 	}
 
 	// Slack 알림 모의 설정
-	receivedMessages := make(chan string, 1)
-	originalSendSlack := sendSlackNotification
-	defer func() { sendSlackNotification = originalSendSlack }()
-
-	sendSlackNotification = func(webhookURL, message string) error {
-		receivedMessages <- message
-		return nil
-	}
+	slack := newFakeNotifier()
 
 	for _, tt := range tests {
 		tt := tt // capture range variable
@@ -223,6 +227,42 @@ func TestReconcileResourceTracker(t *testing.T) {
 					},
 				}
 				clientBuilder = clientBuilder.WithObjects(sts)
+			} else if tt.kind == "DaemonSet" {
+				ds := &appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       tt.resourceName,
+						Namespace:  "default",
+						Generation: 1,
+					},
+					Spec: appsv1.DaemonSetSpec{
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": tt.resourceName},
+						},
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels: map[string]string{"app": tt.resourceName},
+							},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  "app",
+										Image: tt.initialImage,
+									},
+								},
+							},
+						},
+					},
+					Status: appsv1.DaemonSetStatus{
+						DesiredNumberScheduled: tt.replicas,
+						CurrentNumberScheduled: tt.replicas,
+						UpdatedNumberScheduled: tt.readyReplicas,
+						NumberReady:            tt.readyReplicas,
+						NumberAvailable:        tt.readyReplicas,
+						NumberUnavailable:      tt.replicas - tt.readyReplicas,
+						ObservedGeneration:     1,
+					},
+				}
+				clientBuilder = clientBuilder.WithObjects(ds)
 			}
 
 			// ResourceTracker 추가
@@ -233,9 +273,10 @@ func TestReconcileResourceTracker(t *testing.T) {
 			recorder := record.NewFakeRecorder(10)
 
 			r := &ResourceTrackerReconciler{
-				Client:   client,
-				Scheme:   scheme,
-				Recorder: recorder,
+				Client:    client,
+				Scheme:    scheme,
+				Recorder:  recorder,
+				Notifiers: newFakeRegistry(ChannelSlack, slack),
 			}
 
 			// Reconcile 실행
@@ -271,6 +312,8 @@ func TestReconcileResourceTracker(t *testing.T) {
 						expectedEvent = "DeploymentReady"
 					case "StatefulSet":
 						expectedEvent = "StatefulSetReady"
+					case "DaemonSet":
+						expectedEvent = "DaemonSetReady"
 					case "Pod":
 						expectedEvent = "PodReady"
 					}
@@ -281,11 +324,11 @@ func TestReconcileResourceTracker(t *testing.T) {
 
 				// Slack 알림 확인
 				select {
-				case msg := <-receivedMessages:
-					t.Logf("Received Slack message: %s", msg)
-					expectedMsg := formatSlackMessage(tt.kind, "default", tt.resourceName,
+				case event := <-slack.events:
+					t.Logf("Received Slack message: %s", event.Message)
+					expectedMsg := readyMessage(tt.kind, "default", tt.resourceName,
 						tt.readyReplicas, tt.replicas)
-					assert.Equal(t, expectedMsg, msg)
+					assert.Equal(t, expectedMsg, event.Message)
 				case <-time.After(time.Second):
 					t.Error("Expected Slack notification not received")
 				}
@@ -294,18 +337,43 @@ func TestReconcileResourceTracker(t *testing.T) {
 	}
 }
 
+func TestDaemonSetReady(t *testing.T) {
+	rolledOut := appsv1.DaemonSetStatus{
+		ObservedGeneration:     2,
+		DesiredNumberScheduled: 3,
+		UpdatedNumberScheduled: 3,
+		NumberReady:            3,
+		NumberAvailable:        3,
+	}
+	tests := []struct {
+		name   string
+		mutate func(status *appsv1.DaemonSetStatus)
+		ready  bool
+	}{
+		{"rolled out", func(status *appsv1.DaemonSetStatus) {}, true},
+		{"spec not observed yet", func(status *appsv1.DaemonSetStatus) { status.ObservedGeneration = 1 }, false},
+		{"old pods remain", func(status *appsv1.DaemonSetStatus) { status.UpdatedNumberScheduled = 2 }, false},
+		{"pod not ready", func(status *appsv1.DaemonSetStatus) { status.NumberReady = 2 }, false},
+		{"pod unavailable", func(status *appsv1.DaemonSetStatus) { status.NumberUnavailable = 1 }, false},
+		{"no matching nodes", func(status *appsv1.DaemonSetStatus) { *status = appsv1.DaemonSetStatus{ObservedGeneration: 2} }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Generation: 2}, Status: rolledOut}
+			tt.mutate(&ds.Status)
+			assert.Equal(t, tt.ready, daemonSetReady(ds))
+		})
+	}
+}
+
 func TestReconcileEvents(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = ddukbgv1alpha1.AddToScheme(scheme)
 	_ = appsv1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
-	// Slack 알림 테스트를 위한 채널
-	receivedMessages := make(chan string, 1)
-	originalSendSlackNotification := sendSlackNotification
-	defer func() {
-		sendSlackNotification = originalSendSlackNotification
-	}()
+	// Slack 알림 테스트를 위한 fake notifier
+	slack := newFakeNotifier()
 
 	testCases := []struct {
 		name         string
@@ -378,12 +446,6 @@ func TestReconcileEvents(t *testing.T) {
 				},
 			}
 
-			// Mock Slack 알림 함수 설정
-			sendSlackNotification = func(webhookURL string, message string) error {
-				receivedMessages <- message
-				return nil
-			}
-
 			// Fake 클라이언트 및 Recorder 설정
 			recorder := record.NewFakeRecorder(10)
 			client := fake.NewClientBuilder().
@@ -394,9 +456,10 @@ func TestReconcileEvents(t *testing.T) {
 				Build()
 
 			r := &ResourceTrackerReconciler{
-				Client:   client,
-				Scheme:   scheme,
-				Recorder: recorder,
+				Client:    client,
+				Scheme:    scheme,
+				Recorder:  recorder,
+				Notifiers: newFakeRegistry(ChannelSlack, slack),
 			}
 
 			// Reconcile 실행
@@ -446,11 +509,11 @@ func TestReconcileEvents(t *testing.T) {
 			// Slack 알림 확인
 			t.Log("Waiting for Slack notification...")
 			select {
-			case msg := <-receivedMessages:
-				t.Logf("Received Slack message: %s", msg)
-				expectedMsg := formatSlackMessage("Deployment", "default", "test-app",
+			case event := <-slack.events:
+				t.Logf("Received Slack message: %s", event.Message)
+				expectedMsg := readyMessage("Deployment", "default", "test-app",
 					int32(3), int32(3))
-				assert.Equal(t, expectedMsg, msg)
+				assert.Equal(t, expectedMsg, event.Message)
 			case <-time.After(time.Second * 2):
 				t.Error("Expected Slack notification not received")
 			}
@@ -461,3 +524,94 @@ func TestReconcileEvents(t *testing.T) {
 func int32Ptr(i int32) *int32 {
 	return &i
 }
+
+// readyMessage is the built-in ready message of a Deployment or StatefulSet
+func readyMessage(kind, namespace, name string, readyReplicas, totalReplicas int32) string {
+	return fmt.Sprintf("%s %s/%s is now ready\n"+
+		"> Namespace: %s\n"+
+		"> Status: Running\n"+
+		"> Replicas: %d/%d ready",
+		kind, namespace, name,
+		namespace,
+		readyReplicas, totalReplicas)
+}
+
+func TestReconcileChangeEvents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	ctx := context.Background()
+	slack := newFakeNotifier()
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(3),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx:1.24"}}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "Deployment", Namespace: "default"},
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: "https://hooks.slack.com/test"},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker, deploy).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, EventReady, (<-slack.events).Type)
+
+	// 이미지와 레플리카 변경
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-app", Namespace: "default"}, deploy))
+	deploy.Spec.Replicas = int32Ptr(5)
+	deploy.Spec.Template.Spec.Containers[0].Image = "nginx:1.25"
+	require.NoError(t, c.Update(ctx, deploy))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	imageChanged := <-slack.events
+	assert.Equal(t, EventImageChanged, imageChanged.Type)
+	assert.Equal(t, []string{"nginx:1.24"}, imageChanged.PreviousImages)
+	assert.Contains(t, imageChanged.Message, "nginx:1.24 → nginx:1.25")
+
+	scaled := <-slack.events
+	assert.Equal(t, EventScaled, scaled.Type)
+	assert.Equal(t, int32(3), scaled.PreviousReplicas)
+	assert.Contains(t, scaled.Message, "> Replicas: 3 → 5")
+
+	// 삭제
+	require.NoError(t, c.Delete(ctx, deploy))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	deleted := <-slack.events
+	assert.Equal(t, EventDeleted, deleted.Type)
+	assert.Equal(t, "Deployment default/test-app was deleted\n> Namespace: default", deleted.Message)
+	assert.Empty(t, slack.events)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Empty(t, updated.Status.ResourceStatus)
+	assert.Empty(t, updated.Status.ResourceStates)
+	assert.Empty(t, updated.Status.ObservedReplicas)
+}
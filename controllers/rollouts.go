@@ -0,0 +1,315 @@
+// controllers/rollouts.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// rolloutGVK is the Argo Rollouts Rollout kind, read as unstructured objects so
+// that the controller does not depend on Argo Rollouts
+var rolloutGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+
+// rolloutRevisionAnnotation is set by the Argo Rollouts controller on every update
+const rolloutRevisionAnnotation = "rollout.argoproj.io/revision"
+
+// RolloutDetails describes the progress of an Argo Rollout
+type RolloutDetails struct {
+	// Strategy is canary or blueGreen
+	Strategy string `json:"strategy"`
+	// Step is the current canary step index and Steps the number of steps
+	Step  int32 `json:"step"`
+	Steps int32 `json:"steps"`
+	// CanaryWeight is the percentage of traffic sent to the canary
+	CanaryWeight int32 `json:"canaryWeight"`
+	// PauseReasons of the rollout's pause conditions, e.g. CanaryPauseStep
+	PauseReasons []string `json:"pauseReasons,omitempty"`
+	// Analysis is the run an analysis event reports
+	Analysis *AnalysisRunStatus `json:"analysis,omitempty"`
+	// StableRS and CanaryRS are the pod template hashes of the stable and updated ReplicaSets
+	StableRS string `json:"stableRS,omitempty"`
+	CanaryRS string `json:"canaryRS,omitempty"`
+}
+
+// AnalysisRunStatus is the status of an AnalysisRun started by a Rollout
+type AnalysisRunStatus struct {
+	Name string `json:"name"`
+	// Status is Pending, Running, Successful, Failed, Error or Inconclusive
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// key distinguishes the progress of a rollout in dedup keys
+func (d *RolloutDetails) key() string {
+	if d == nil {
+		return ""
+	}
+	key := fmt.Sprintf("%d/%d@%d:%s", d.Step, d.Steps, d.CanaryWeight, strings.Join(d.PauseReasons, ","))
+	if d.Analysis != nil {
+		key += ":" + d.Analysis.Name + "=" + d.Analysis.Status
+	}
+	return key
+}
+
+// analysisRunFinished reports whether an AnalysisRun status is final
+func analysisRunFinished(status string) bool {
+	switch status {
+	case "Successful", "Failed", "Error", "Inconclusive":
+		return true
+	}
+	return false
+}
+
+// reconcileRollout handles Argo Rollouts
+func (r *ResourceTrackerReconciler) reconcileRollout(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (ctrl.Result, error) {
+	return r.reconcileUnstructured(ctx, tracker, rolloutGVK, r.observeRollout)
+}
+
+// observeRollout is the observeFunc of Argo Rollouts. Besides readiness, it
+// notifies canary steps, pauses, finished analysis runs, aborts and promotions.
+func (r *ResourceTrackerReconciler) observeRollout(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	u *unstructured.Unstructured) (statusChanged bool, event NotificationEvent) {
+	logger := log.FromContext(ctx)
+
+	key := fmt.Sprintf("%s/%s", u.GetNamespace(), u.GetName())
+	phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+	// Rollout의 observedGeneration은 문자열
+	observed, _, _ := unstructured.NestedString(u.Object, "status", "observedGeneration")
+	isReady := phase == "Healthy" && (observed == "" || observed == strconv.FormatInt(u.GetGeneration(), 10))
+
+	details, state, analyses := rolloutProgress(u)
+	event = rolloutEvent(u, phase, details)
+	change := recordResourceState(tracker, key, isReady, &event)
+	statusChanged = change.statusChanged
+	r.notifyChanges(ctx, tracker, event, change)
+
+	if tracker.Status.ResourceStatus[key] != isReady {
+		statusChanged = true
+		tracker.Status.ResourceStatus[key] = isReady
+
+		ready := event
+		if isReady {
+			r.Recorder.Event(tracker, corev1.EventTypeNormal, "RolloutReady",
+				fmt.Sprintf("Rollout %s is ready", key))
+			ready.Type = EventReady
+		} else {
+			ready.Type = EventNotReady
+		}
+		if err := r.sendNotifications(ctx, tracker, ready); err != nil {
+			logger.Error(err, "Failed to send notification")
+		}
+	}
+
+	// 처음 관찰한 Rollout은 진행 상태만 기록
+	previous, seen := tracker.Status.Rollouts[key]
+	if seen {
+		for _, progress := range rolloutChanges(previous, state, event, analyses) {
+			switch progress.Type {
+			case EventRolloutAborted:
+				r.Recorder.Event(tracker, corev1.EventTypeWarning, "RolloutAborted", eventTitle(progress))
+			case EventRolloutPromoted:
+				r.Recorder.Event(tracker, corev1.EventTypeNormal, "RolloutPromoted", eventTitle(progress))
+			}
+			if err := r.sendNotifications(ctx, tracker, progress); err != nil {
+				logger.Error(err, "Failed to send notification")
+			}
+		}
+	}
+	if !seen || !reflect.DeepEqual(previous, state) {
+		if tracker.Status.Rollouts == nil {
+			tracker.Status.Rollouts = make(map[string]ddukbgv1alpha1.RolloutState)
+		}
+		tracker.Status.Rollouts[key] = state
+		statusChanged = true
+	}
+
+	reason, detail := rolloutFailure(u, phase)
+	if r.updateFailureStatus(ctx, tracker, event, reason, detail) {
+		statusChanged = true
+	}
+	return statusChanged, event
+}
+
+// rolloutChanges returns the progress events between two observations of a rollout
+func rolloutChanges(previous, current ddukbgv1alpha1.RolloutState, event NotificationEvent,
+	analyses []AnalysisRunStatus) []NotificationEvent {
+	var events []NotificationEvent
+	progress := func(eventType string) NotificationEvent {
+		e := event
+		e.Type = eventType
+		details := *event.Rollout
+		e.Rollout = &details
+		return e
+	}
+
+	for _, run := range analyses {
+		if !analysisRunFinished(run.Status) || previous.AnalysisRuns[run.Name] == run.Status {
+			continue
+		}
+		e := progress(EventAnalysis)
+		run := run
+		e.Rollout.Analysis = &run
+		e.Reason, e.Detail = run.Status, run.Message
+		if run.Status != "Successful" {
+			e.Severity = SeverityWarning
+		}
+		events = append(events, e)
+	}
+
+	for _, reason := range current.PauseReasons {
+		if !slices.Contains(previous.PauseReasons, reason) {
+			events = append(events, progress(EventRolloutPaused))
+			break
+		}
+	}
+
+	// 중단되면 단계가 0으로 돌아가고, 마지막 단계에 도달하면 promote 알림으로 대신함
+	if current.Step != nil && !current.Aborted && *current.Step < event.Rollout.Steps &&
+		(previous.Step == nil || *previous.Step != *current.Step) {
+		events = append(events, progress(EventRolloutStep))
+	}
+
+	if current.Aborted && !previous.Aborted {
+		e := progress(EventRolloutAborted)
+		e.Reason = "RolloutAborted"
+		e.Detail = event.Detail
+		events = append(events, e)
+	}
+
+	if previous.StableRS != "" && current.StableRS != previous.StableRS {
+		events = append(events, progress(EventRolloutPromoted))
+	}
+	return events
+}
+
+// rolloutProgress reads the progress of a rollout: the details notified, the
+// state compared with the next observation, and the current analysis runs
+func rolloutProgress(u *unstructured.Unstructured) (RolloutDetails, ddukbgv1alpha1.RolloutState, []AnalysisRunStatus) {
+	details := RolloutDetails{}
+	state := ddukbgv1alpha1.RolloutState{}
+
+	var runPaths [][]string
+	if _, found, _ := unstructured.NestedMap(u.Object, "spec", "strategy", "blueGreen"); found {
+		details.Strategy = "blueGreen"
+		runPaths = [][]string{
+			{"status", "blueGreen", "prePromotionAnalysisRunStatus"},
+			{"status", "blueGreen", "postPromotionAnalysisRunStatus"},
+		}
+	} else {
+		details.Strategy = "canary"
+		runPaths = [][]string{
+			{"status", "canary", "currentStepAnalysisRunStatus"},
+			{"status", "canary", "currentBackgroundAnalysisRunStatus"},
+		}
+	}
+
+	steps, _, _ := unstructured.NestedSlice(u.Object, "spec", "strategy", "canary", "steps")
+	details.Steps = int32(len(steps))
+	if index, found, _ := unstructured.NestedInt64(u.Object, "status", "currentStepIndex"); found {
+		step := int32(index)
+		details.Step = step
+		state.Step = &step
+	}
+	details.CanaryWeight = canaryWeight(u, steps, details.Step)
+
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "pauseConditions")
+	for _, condition := range conditions {
+		if fields, ok := condition.(map[string]interface{}); ok {
+			if reason, _, _ := unstructured.NestedString(fields, "reason"); reason != "" {
+				details.PauseReasons = append(details.PauseReasons, reason)
+			}
+		}
+	}
+	state.PauseReasons = details.PauseReasons
+
+	var analyses []AnalysisRunStatus
+	for _, path := range runPaths {
+		fields, found, _ := unstructured.NestedMap(u.Object, path...)
+		if !found {
+			continue
+		}
+		run := AnalysisRunStatus{}
+		run.Name, _, _ = unstructured.NestedString(fields, "name")
+		run.Status, _, _ = unstructured.NestedString(fields, "status")
+		run.Message, _, _ = unstructured.NestedString(fields, "message")
+		if run.Name == "" {
+			continue
+		}
+		analyses = append(analyses, run)
+		if state.AnalysisRuns == nil {
+			state.AnalysisRuns = make(map[string]string)
+		}
+		state.AnalysisRuns[run.Name] = run.Status
+	}
+
+	state.Aborted, _, _ = unstructured.NestedBool(u.Object, "status", "abort")
+	details.StableRS, _, _ = unstructured.NestedString(u.Object, "status", "stableRS")
+	details.CanaryRS, _, _ = unstructured.NestedString(u.Object, "status", "currentPodHash")
+	state.StableRS = details.StableRS
+	return details, state, analyses
+}
+
+// canaryWeight returns the traffic weight of the canary: the weight reported by
+// the traffic router, or else the last setWeight step reached, like Argo Rollouts
+func canaryWeight(u *unstructured.Unstructured, steps []interface{}, step int32) int32 {
+	if weight, found, _ := unstructured.NestedInt64(u.Object, "status", "canary", "weights", "canary", "weight"); found {
+		return int32(weight)
+	}
+	if len(steps) == 0 || int(step) >= len(steps) {
+		return 100
+	}
+	for i := int(step); i >= 0; i-- {
+		fields, ok := steps[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if weight, found, _ := unstructured.NestedInt64(fields, "setWeight"); found {
+			return int32(weight)
+		}
+	}
+	return 0
+}
+
+func rolloutEvent(u *unstructured.Unstructured, phase string, details RolloutDetails) NotificationEvent {
+	event := genericEvent(u, phase)
+	event.Kind = "Rollout"
+	// spec.replicas가 없으면 1
+	if _, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas"); !found {
+		readyReplicas, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+		event.ReadyReplicas, event.TotalReplicas = int32(readyReplicas), 1
+	}
+	event.Revision = u.GetAnnotations()[rolloutRevisionAnnotation]
+	event.Detail, _, _ = unstructured.NestedString(u.Object, "status", "message")
+	event.Rollout = &details
+	return event
+}
+
+// rolloutFailure reports a Degraded rollout, e.g. aborted or past its progress
+// deadline, with the reason of its Progressing condition
+func rolloutFailure(u *unstructured.Unstructured, phase string) (reason, detail string) {
+	if phase != "Degraded" {
+		return "", ""
+	}
+	reason = "Degraded"
+	detail, _, _ = unstructured.NestedString(u.Object, "status", "message")
+	if c, ok := genericConditions(u)["Progressing"]; ok && c.status == string(corev1.ConditionFalse) && c.reason != "" {
+		reason = c.reason
+		if c.message != "" {
+			detail = c.message
+		}
+	}
+	return reason, detail
+}
@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// canaryRollout returns a Rollout with three canary steps: setWeight 20, pause, analysis
+func canaryRollout(image string, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(5),
+			"strategy": map[string]interface{}{
+				"canary": map[string]interface{}{
+					"steps": []interface{}{
+						map[string]interface{}{"setWeight": int64(20)},
+						map[string]interface{}{"pause": map[string]interface{}{}},
+						map[string]interface{}{"analysis": map[string]interface{}{"templates": []interface{}{}}},
+					},
+				},
+			},
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"name": "api", "image": image}},
+				},
+			},
+		},
+		"status": status,
+	}}
+	u.SetGroupVersionKind(rolloutGVK)
+	u.SetNamespace("default")
+	u.SetName("api")
+	return u
+}
+
+func rolloutStatus(phase, stableRS, currentPodHash string, step int64) map[string]interface{} {
+	return map[string]interface{}{
+		"phase":            phase,
+		"stableRS":         stableRS,
+		"currentPodHash":   currentPodHash,
+		"currentStepIndex": step,
+		"readyReplicas":    int64(5),
+	}
+}
+
+func TestReconcileRollout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(ddukbgv1alpha1.GroupVersion.WithKind("ResourceTracker"), meta.RESTScopeNamespace)
+	mapper.Add(rolloutGVK, meta.RESTScopeNamespace)
+
+	slack := newFakeNotifier()
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "Rollout", Name: "api", Namespace: "default"},
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: "https://hooks.slack.com/test", AlertOnFail: true},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(mapper).
+		WithObjects(tracker, canaryRollout("api:1", rolloutStatus("Healthy", "aaa", "aaa", 3))).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(20),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-tracker", Namespace: "default"}}
+	// advance updates the rollout and returns the notifications sent for it
+	advance := func(image string, status map[string]interface{}) []NotificationEvent {
+		rollout := &unstructured.Unstructured{}
+		rollout.SetGroupVersionKind(rolloutGVK)
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "api"}, rollout))
+		updated := canaryRollout(image, status)
+		updated.SetResourceVersion(rollout.GetResourceVersion())
+		require.NoError(t, c.Update(ctx, updated))
+
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		var events []NotificationEvent
+		for len(slack.events) > 0 {
+			events = append(events, <-slack.events)
+		}
+		return events
+	}
+	eventTypes := func(events []NotificationEvent) []string {
+		var result []string
+		for _, event := range events {
+			result = append(result, event.Type)
+		}
+		return result
+	}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, EventReady, (<-slack.events).Type)
+
+	// 새 리비전의 첫 단계
+	events := advance("api:2", rolloutStatus("Progressing", "aaa", "bbb", 0))
+	require.Equal(t, []string{EventImageChanged, EventRolloutStep}, eventTypes(events))
+	assert.Equal(t, int32(0), events[1].Rollout.Step)
+	assert.Equal(t, int32(3), events[1].Rollout.Steps)
+	assert.Equal(t, int32(20), events[1].Rollout.CanaryWeight)
+	assert.Equal(t, "Rollout default/api is at step 0/3\n> Namespace: default\n> Canary weight: 20%", events[1].Message)
+
+	// pause 단계
+	paused := rolloutStatus("Paused", "aaa", "bbb", 1)
+	paused["pauseConditions"] = []interface{}{map[string]interface{}{"reason": "CanaryPauseStep"}}
+	events = advance("api:2", paused)
+	require.Equal(t, []string{EventRolloutPaused, EventRolloutStep}, eventTypes(events))
+	assert.Equal(t, []string{"CanaryPauseStep"}, events[0].Rollout.PauseReasons)
+	assert.Equal(t, int32(20), events[1].Rollout.CanaryWeight)
+	assert.Empty(t, advance("api:2", paused), "nothing changed")
+
+	// promote 후 분석 단계
+	analyzing := rolloutStatus("Progressing", "aaa", "bbb", 2)
+	analyzing["canary"] = map[string]interface{}{
+		"currentStepAnalysisRunStatus": map[string]interface{}{"name": "api-bbb-2", "status": "Running"},
+	}
+	events = advance("api:2", analyzing)
+	require.Equal(t, []string{EventRolloutStep}, eventTypes(events))
+
+	// 분석 실패로 중단
+	aborted := rolloutStatus("Degraded", "aaa", "bbb", 0)
+	aborted["abort"] = true
+	aborted["message"] = "RolloutAborted: Rollout aborted update to revision 2: Metric \"error-rate\" assessed Failed"
+	aborted["canary"] = map[string]interface{}{
+		"currentStepAnalysisRunStatus": map[string]interface{}{"name": "api-bbb-2", "status": "Failed",
+			"message": "Metric \"error-rate\" assessed Failed"},
+	}
+	aborted["conditions"] = []interface{}{map[string]interface{}{
+		"type": "Progressing", "status": "False", "reason": "RolloutAborted", "message": aborted["message"],
+	}}
+	events = advance("api:2", aborted)
+	require.Equal(t, []string{EventAnalysis, EventRolloutAborted, EventFailed}, eventTypes(events))
+	assert.Equal(t, "Failed", events[0].Reason)
+	assert.Equal(t, SeverityWarning, events[0].Severity)
+	assert.Equal(t, "api-bbb-2", events[0].Rollout.Analysis.Name)
+	assert.Contains(t, events[1].Detail, "assessed Failed")
+	assert.Equal(t, "RolloutAborted", events[2].Reason)
+
+	// 다시 시도해 분석 성공 후 promote
+	analyzing["canary"] = map[string]interface{}{
+		"currentStepAnalysisRunStatus": map[string]interface{}{"name": "api-bbb-2.1", "status": "Running"},
+	}
+	require.Equal(t, []string{EventRolloutStep}, eventTypes(advance("api:2", analyzing)))
+	promoted := rolloutStatus("Healthy", "bbb", "bbb", 3)
+	promoted["canary"] = map[string]interface{}{
+		"currentStepAnalysisRunStatus": map[string]interface{}{"name": "api-bbb-2.1", "status": "Successful"},
+	}
+	events = advance("api:2", promoted)
+	require.Equal(t, []string{EventReady, EventAnalysis, EventRolloutPromoted}, eventTypes(events))
+	assert.Equal(t, "Successful", events[1].Reason)
+	assert.Equal(t, SeverityInfo, events[1].Severity)
+	assert.Equal(t, int32(100), events[2].Rollout.CanaryWeight)
+}
+
+func TestCanaryWeight(t *testing.T) {
+	rollout := canaryRollout("api:1", map[string]interface{}{})
+	steps, _, _ := unstructured.NestedSlice(rollout.Object, "spec", "strategy", "canary", "steps")
+	assert.Equal(t, int32(20), canaryWeight(rollout, steps, 0))
+	assert.Equal(t, int32(20), canaryWeight(rollout, steps, 2))
+	assert.Equal(t, int32(100), canaryWeight(rollout, steps, 3))
+
+	// 트래픽 라우터가 보고한 가중치 우선
+	require.NoError(t, unstructured.SetNestedField(rollout.Object, int64(15), "status", "canary", "weights", "canary", "weight"))
+	assert.Equal(t, int32(15), canaryWeight(rollout, steps, 0))
+}
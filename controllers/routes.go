@@ -0,0 +1,121 @@
+// controllers/routes.go
+
+package controllers
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// Notification event severities used by route matchers
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// eventSeverity derives the severity of an event type
+func eventSeverity(eventType string) string {
+	switch eventType {
+	case EventFailed:
+		return SeverityCritical
+	case EventNotReady, EventDeleted, EventRolloutAborted:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// routeChannels returns the channels the tracker's routes select for the event.
+// It returns nil when the tracker has no routes, meaning every channel.
+func routeChannels(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) map[string]bool {
+	routes := tracker.Spec.Notify.Routes
+	if len(routes) == 0 {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	selected := make(map[string]bool)
+	for i, route := range routes {
+		matched, err := routeMatches(route.Match, event)
+		if err != nil {
+			logger.Error(err, "Invalid notification route", "route", routeName(route, i))
+			continue
+		}
+		if !matched {
+			continue
+		}
+		for _, channel := range route.Channels {
+			selected[channel] = true
+		}
+		if !route.Continue {
+			break
+		}
+	}
+
+	if len(selected) == 0 {
+		logger.V(1).Info("No notification route matched", "event", event.Type,
+			"resource", event.Namespace+"/"+event.Name)
+	}
+	return selected
+}
+
+// routeMatches reports whether every matcher given in match accepts the event
+func routeMatches(match ddukbgv1alpha1.RouteMatch, event NotificationEvent) (bool, error) {
+	if len(match.Events) > 0 && !slices.Contains(match.Events, event.Type) {
+		return false, nil
+	}
+	if len(match.Severities) > 0 && !slices.Contains(match.Severities, event.Severity) {
+		return false, nil
+	}
+	if match.Labels != nil {
+		selector, err := metav1.LabelSelectorAsSelector(match.Labels)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(event.Labels)) {
+			return false, nil
+		}
+	}
+	for key, value := range match.Annotations {
+		if actual, ok := event.Annotations[key]; !ok || actual != value {
+			return false, nil
+		}
+	}
+	if len(match.Images) > 0 {
+		return imagesMatch(match.Images, event.Images)
+	}
+	return true, nil
+}
+
+// imagesMatch reports whether any image matches any of the glob patterns
+func imagesMatch(patterns, images []string) (bool, error) {
+	for _, pattern := range patterns {
+		for _, image := range images {
+			matched, err := path.Match(pattern, image)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// routeName names a route in logs, falling back to its position
+func routeName(route ddukbgv1alpha1.NotificationRoute, index int) string {
+	if route.Name != "" {
+		return route.Name
+	}
+	return "#" + strconv.Itoa(index)
+}
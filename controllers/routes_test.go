@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteChannels(t *testing.T) {
+	ctx := context.Background()
+	critical := ddukbgv1alpha1.NotificationRoute{
+		Name: "critical-failures",
+		Match: ddukbgv1alpha1.RouteMatch{
+			Events: []string{EventFailed, EventReady},
+			Labels: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+		},
+		Channels: []string{ChannelPagerDuty},
+		Continue: true,
+	}
+	payments := ddukbgv1alpha1.NotificationRoute{
+		Match: ddukbgv1alpha1.RouteMatch{
+			Severities:  []string{SeverityCritical},
+			Annotations: map[string]string{"team": "payments"},
+			Images:      []string{"registry.example.com/payments/*"},
+		},
+		Channels: []string{ChannelOpsgenie},
+	}
+	catchAll := ddukbgv1alpha1.NotificationRoute{Channels: []string{ChannelSlack}}
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{}
+	event := NotificationEvent{Type: EventReady, Severity: SeverityInfo, Name: "test-app"}
+	assert.Nil(t, routeChannels(ctx, tracker, event), "without routes every channel is used")
+
+	tracker.Spec.Notify.Routes = []ddukbgv1alpha1.NotificationRoute{critical, payments, catchAll}
+	cases := []struct {
+		name     string
+		event    NotificationEvent
+		expected map[string]bool
+	}{
+		{
+			name: "critical failure continues to the catch-all route",
+			event: NotificationEvent{Type: EventFailed, Severity: SeverityCritical,
+				Labels: map[string]string{"tier": "critical"}},
+			expected: map[string]bool{ChannelPagerDuty: true, ChannelSlack: true},
+		},
+		{
+			name: "payments failure stops at the payments route",
+			event: NotificationEvent{Type: EventFailed, Severity: SeverityCritical,
+				Annotations: map[string]string{"team": "payments"},
+				Images:      []string{"nginx:1.25", "registry.example.com/payments/api:v2"}},
+			expected: map[string]bool{ChannelOpsgenie: true},
+		},
+		{
+			name: "payments image change has the wrong severity",
+			event: NotificationEvent{Type: EventImageChanged, Severity: SeverityInfo,
+				Annotations: map[string]string{"team": "payments"},
+				Images:      []string{"registry.example.com/payments/api:v2"}},
+			expected: map[string]bool{ChannelSlack: true},
+		},
+		{
+			name: "image outside the pattern",
+			event: NotificationEvent{Type: EventFailed, Severity: SeverityCritical,
+				Annotations: map[string]string{"team": "payments"},
+				Images:      []string{"registry.example.com/payments/api/v2:latest"}},
+			expected: map[string]bool{ChannelSlack: true},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, routeChannels(ctx, tracker, tc.event))
+		})
+	}
+
+	tracker.Spec.Notify.Routes = []ddukbgv1alpha1.NotificationRoute{critical}
+	assert.Empty(t, routeChannels(ctx, tracker, event), "unmatched events are not sent")
+
+	invalid := ddukbgv1alpha1.NotificationRoute{
+		Match:    ddukbgv1alpha1.RouteMatch{Images: []string{"["}},
+		Channels: []string{ChannelPagerDuty},
+	}
+	tracker.Spec.Notify.Routes = []ddukbgv1alpha1.NotificationRoute{invalid, catchAll}
+	assert.Equal(t, map[string]bool{ChannelSlack: true},
+		routeChannels(ctx, tracker, NotificationEvent{Images: []string{"nginx"}}), "invalid routes are skipped")
+}
+
+func TestSendNotificationsRoutes(t *testing.T) {
+	slack := newFakeNotifier()
+	pagerDuty := newFakeNotifier()
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, slack)
+	registry.Register(ChannelPagerDuty, pagerDuty)
+
+	r := &ResourceTrackerReconciler{Notifiers: registry}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				Routes: []ddukbgv1alpha1.NotificationRoute{
+					{
+						Match: ddukbgv1alpha1.RouteMatch{
+							Events: []string{EventFailed},
+							Labels: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+						},
+						Channels: []string{ChannelPagerDuty},
+					},
+					{Channels: []string{ChannelSlack}},
+				},
+			},
+		},
+	}
+
+	failed := NotificationEvent{Type: EventFailed, Kind: "Deployment", Namespace: "default", Name: "api",
+		Labels: map[string]string{"tier": "critical"}}
+	require.NoError(t, r.sendNotifications(context.Background(), tracker, failed))
+	received := <-pagerDuty.events
+	assert.Equal(t, SeverityCritical, received.Severity)
+	assert.Empty(t, slack.events)
+
+	ready := NotificationEvent{Type: EventReady, Kind: "Deployment", Namespace: "default", Name: "api",
+		Labels: map[string]string{"tier": "critical"}}
+	require.NoError(t, r.sendNotifications(context.Background(), tracker, ready))
+	received = <-slack.events
+	assert.Equal(t, SeverityInfo, received.Severity)
+	assert.Empty(t, pagerDuty.events)
+}
@@ -0,0 +1,306 @@
+// controllers/schedule.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// Actions taken on non-critical notifications during quiet hours
+const (
+	QuietActionSuppress = "suppress"
+	QuietActionDigest   = "digest"
+)
+
+// MaintenanceScheduleKey is the ConfigMap key holding the cluster-wide maintenance
+// windows, in the same format as spec.notify.schedule
+const MaintenanceScheduleKey = "schedule"
+
+// quietDigestGroup separates the digests held back by quiet hours from digest windows
+const quietDigestGroup = "quiet-hours"
+
+// maxQuietWindow bounds how far back the start of a cron window is searched
+const maxQuietWindow = 7 * 24 * time.Hour
+
+var weekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// quietHours reports whether notifications are held back at now, by the tracker's
+// schedule or else by the cluster-wide maintenance windows, and until when
+func (r *ResourceTrackerReconciler) quietHours(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker,
+	now time.Time) (until time.Time, action string, quiet bool) {
+	logger := log.FromContext(ctx)
+
+	if schedule := tracker.Spec.Notify.Schedule; schedule != nil {
+		until, quiet, err := quietUntil(schedule, now)
+		if err != nil {
+			logger.Error(err, "Ignoring invalid notification schedule")
+		} else if quiet {
+			return until, quietAction(schedule), true
+		}
+	}
+
+	schedule, err := r.maintenanceSchedule(ctx)
+	if err != nil {
+		logger.Error(err, "Ignoring maintenance windows", "configMap", r.MaintenanceConfigMap)
+		return time.Time{}, "", false
+	}
+	if schedule == nil {
+		return time.Time{}, "", false
+	}
+	until, quiet, err = quietUntil(schedule, now)
+	if err != nil {
+		logger.Error(err, "Ignoring invalid maintenance windows", "configMap", r.MaintenanceConfigMap)
+		return time.Time{}, "", false
+	}
+	return until, quietAction(schedule), quiet
+}
+
+// maintenanceSchedule reads the cluster-wide maintenance windows, if configured.
+// The ConfigMap is read from the API server, so that reading it does not start
+// an informer caching every ConfigMap in the cluster.
+func (r *ResourceTrackerReconciler) maintenanceSchedule(ctx context.Context) (*ddukbgv1alpha1.NotifySchedule, error) {
+	if r.MaintenanceConfigMap == (types.NamespacedName{}) {
+		return nil, nil
+	}
+
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, r.MaintenanceConfigMap, cm); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	data, ok := cm.Data[MaintenanceScheduleKey]
+	if !ok {
+		return nil, nil
+	}
+
+	schedule := &ddukbgv1alpha1.NotifySchedule{}
+	if err := yaml.UnmarshalStrict([]byte(data), schedule); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", MaintenanceScheduleKey, err)
+	}
+	return schedule, nil
+}
+
+func quietAction(schedule *ddukbgv1alpha1.NotifySchedule) string {
+	if schedule.Action == "" {
+		return QuietActionDigest
+	}
+	return schedule.Action
+}
+
+// quietUntil returns the end of the latest ending quiet window now falls in
+func quietUntil(schedule *ddukbgv1alpha1.NotifySchedule, now time.Time) (time.Time, bool, error) {
+	loc := time.UTC
+	if schedule.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid time zone %q: %w", schedule.TimeZone, err)
+		}
+	}
+	now = now.In(loc)
+
+	var until time.Time
+	for i, window := range schedule.QuietHours {
+		end, active, err := windowEnd(window, now)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("quietHours[%d]: %w", i, err)
+		}
+		if active && end.After(until) {
+			until = end
+		}
+	}
+	return until, !until.IsZero(), nil
+}
+
+// windowEnd returns the end of the window occurrence now falls in, if any
+func windowEnd(window ddukbgv1alpha1.QuietWindow, now time.Time) (time.Time, bool, error) {
+	if window.Cron != "" {
+		return cronWindowEnd(window, now)
+	}
+
+	start, err := parseTimeOfDay(window.Start)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	end, err := parseTimeOfDay(window.End)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	// 자정을 넘기는 구간은 전날 시작한 구간도 확인
+	for _, offset := range []int{0, -1} {
+		day := time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, now.Location())
+		if len(window.Days) > 0 && !slices.Contains(window.Days, weekdays[day.Weekday()]) {
+			continue
+		}
+		from := day.Add(start)
+		to := day.Add(end)
+		if end <= start {
+			to = to.AddDate(0, 0, 1)
+		}
+		if !now.Before(from) && now.Before(to) {
+			return to, true, nil
+		}
+	}
+	return time.Time{}, false, nil
+}
+
+// parseTimeOfDay parses "HH:MM" into the time since midnight
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// cronWindowEnd finds the latest cron firing within Duration before now
+func cronWindowEnd(window ddukbgv1alpha1.QuietWindow, now time.Time) (time.Time, bool, error) {
+	schedule, err := parseCron(window.Cron)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if window.Duration == nil || window.Duration.Duration <= 0 {
+		return time.Time{}, false, fmt.Errorf("cron window %q needs a duration", window.Cron)
+	}
+	duration := window.Duration.Duration
+	if duration > maxQuietWindow {
+		return time.Time{}, false, fmt.Errorf("cron window %q is longer than %s", window.Cron, maxQuietWindow)
+	}
+
+	start, ok := schedule.previous(now, now.Add(-duration))
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	return start.Add(duration), true, nil
+}
+
+// cronSchedule is a parsed five field cron expression, one bit per allowed value
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar mark unrestricted day fields; when both day fields are
+	// restricted a day matching either of them matches, as in cron
+	domStar, dowStar bool
+}
+
+// parseCron parses "minute hour day-of-month month day-of-week" with *, lists,
+// ranges and steps, e.g. "*/15 22-23,0-6 * * 1-5"
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields", expr)
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	var bits [5]uint64
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseCronField(field, bounds[i][0], bounds[i][1]); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+	}
+	// 요일의 7은 일요일
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &cronSchedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = strconv.Atoi(from); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func (s *cronSchedule) matches(t time.Time) bool {
+	return s.minute&(1<<t.Minute()) != 0 && s.hour&(1<<t.Hour()) != 0 && s.dayMatches(t)
+}
+
+// dayMatches reports whether the schedule fires on the day of t
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	if s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<t.Day()) != 0
+	dowMatch := s.dow&(1<<int(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// previous returns the latest firing in (after, t], skipping whole days and hours
+// that do not match so that sparse schedules are searched quickly
+func (s *cronSchedule) previous(t, after time.Time) (time.Time, bool) {
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+	for t.After(after) {
+		switch {
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case s.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(-time.Minute)
+		case s.minute&(1<<t.Minute()) != 0:
+			return t, true
+		default:
+			t = t.Add(-time.Minute)
+		}
+	}
+	return time.Time{}, false
+}
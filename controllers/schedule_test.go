@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietUntil(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+	// 2026-10-16은 금요일
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, seoul)
+	}
+
+	nightly := &ddukbgv1alpha1.NotifySchedule{
+		TimeZone:   "Asia/Seoul",
+		QuietHours: []ddukbgv1alpha1.QuietWindow{{Start: "22:00", End: "07:00"}},
+	}
+	cases := []struct {
+		name     string
+		schedule *ddukbgv1alpha1.NotifySchedule
+		now      time.Time
+		until    time.Time
+	}{
+		{"before midnight", nightly, at(16, 23, 30), at(17, 7, 0)},
+		{"after midnight", nightly, at(17, 3, 0), at(17, 7, 0)},
+		{"daytime", nightly, at(16, 12, 0), time.Time{}},
+		{"end is exclusive", nightly, at(17, 7, 0), time.Time{}},
+		{"time zone", nightly, time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC), at(17, 7, 0)},
+		{
+			name: "weekend only, started on Saturday",
+			schedule: &ddukbgv1alpha1.NotifySchedule{
+				TimeZone:   "Asia/Seoul",
+				QuietHours: []ddukbgv1alpha1.QuietWindow{{Start: "20:00", End: "09:00", Days: []string{"Sat", "Sun"}}},
+			},
+			now:   at(18, 1, 0),
+			until: at(18, 9, 0),
+		},
+		{
+			name: "weekend only, on a Friday",
+			schedule: &ddukbgv1alpha1.NotifySchedule{
+				TimeZone:   "Asia/Seoul",
+				QuietHours: []ddukbgv1alpha1.QuietWindow{{Start: "20:00", End: "09:00", Days: []string{"Sat", "Sun"}}},
+			},
+			now:   at(16, 21, 0),
+			until: time.Time{},
+		},
+		{
+			name: "cron window",
+			schedule: &ddukbgv1alpha1.NotifySchedule{
+				TimeZone: "Asia/Seoul",
+				QuietHours: []ddukbgv1alpha1.QuietWindow{
+					{Cron: "0 2 * * 1-5", Duration: &metav1.Duration{Duration: 3 * time.Hour}},
+				},
+			},
+			now:   at(16, 4, 59),
+			until: at(16, 5, 0),
+		},
+		{
+			name: "weekly cron window",
+			schedule: &ddukbgv1alpha1.NotifySchedule{
+				TimeZone: "Asia/Seoul",
+				QuietHours: []ddukbgv1alpha1.QuietWindow{
+					{Cron: "0 18 * * 5", Duration: &metav1.Duration{Duration: 63 * time.Hour}},
+				},
+			},
+			now:   at(19, 8, 0),
+			until: at(19, 9, 0),
+		},
+		{
+			name: "latest ending window wins",
+			schedule: &ddukbgv1alpha1.NotifySchedule{
+				QuietHours: []ddukbgv1alpha1.QuietWindow{
+					{Start: "01:00", End: "02:00"},
+					{Cron: "30 0 * * *", Duration: &metav1.Duration{Duration: 4 * time.Hour}},
+				},
+			},
+			now:   time.Date(2026, 10, 16, 1, 30, 0, 0, time.UTC),
+			until: time.Date(2026, 10, 16, 4, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			until, quiet, err := quietUntil(tc.schedule, tc.now)
+			require.NoError(t, err)
+			assert.Equal(t, !tc.until.IsZero(), quiet)
+			if quiet {
+				assert.True(t, tc.until.Equal(until), "expected %s, got %s", tc.until, until)
+			}
+		})
+	}
+
+	_, _, err = quietUntil(&ddukbgv1alpha1.NotifySchedule{TimeZone: "Mars/Olympus"}, at(16, 0, 0))
+	assert.Error(t, err)
+	_, _, err = quietUntil(&ddukbgv1alpha1.NotifySchedule{
+		QuietHours: []ddukbgv1alpha1.QuietWindow{{Start: "25:00", End: "07:00"}}}, at(16, 0, 0))
+	assert.Error(t, err)
+	_, _, err = quietUntil(&ddukbgv1alpha1.NotifySchedule{
+		QuietHours: []ddukbgv1alpha1.QuietWindow{{Cron: "0 2 * * *"}}}, at(16, 0, 0))
+	assert.Error(t, err, "cron windows need a duration")
+}
+
+func TestParseCron(t *testing.T) {
+	schedule, err := parseCron("*/15 22-23,0-6 * * 1-5")
+	require.NoError(t, err)
+	assert.True(t, schedule.matches(time.Date(2026, 10, 16, 23, 45, 0, 0, time.UTC)))
+	assert.False(t, schedule.matches(time.Date(2026, 10, 16, 23, 50, 0, 0, time.UTC)))
+	assert.False(t, schedule.matches(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)))
+	assert.False(t, schedule.matches(time.Date(2026, 10, 17, 23, 45, 0, 0, time.UTC)), "Saturday")
+
+	sunday, err := parseCron("0 0 * * 7")
+	require.NoError(t, err)
+	assert.True(t, sunday.matches(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)))
+
+	// 일자와 요일이 모두 지정되면 둘 중 하나만 맞아도 실행
+	either, err := parseCron("0 0 1 * 5")
+	require.NoError(t, err)
+	assert.True(t, either.matches(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, either.matches(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, either.matches(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)))
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "* * * * 8", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		_, err := parseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestSendNotificationsQuietHours(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	// 지금을 포함하는 유지보수 창
+	maintenance := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "watcher-system"},
+		Data: map[string]string{MaintenanceScheduleKey: `
+action: suppress
+quietHours:
+  - cron: "* * * * *"
+    duration: 1h
+`},
+	}
+	// 유지보수 창은 캐시를 거치지 않고 API 서버에서 읽음
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(maintenance).Build()
+
+	slack := newFakeNotifier()
+	pagerDuty := newFakeNotifier()
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, slack)
+	registry.Register(ChannelPagerDuty, pagerDuty)
+	r := &ResourceTrackerReconciler{
+		Client:               c,
+		APIReader:            apiReader,
+		Notifiers:            registry,
+		MaintenanceConfigMap: types.NamespacedName{Namespace: "watcher-system", Name: "maintenance"},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+	}
+
+	ctx := context.Background()
+	ready := NotificationEvent{Type: EventReady, Kind: "Deployment", Namespace: "default", Name: "batch"}
+	require.NoError(t, r.sendNotifications(ctx, tracker, ready))
+	assert.Empty(t, slack.events, "suppressed by the maintenance window")
+	assert.Len(t, pagerDuty.events, 1, "incident channels are never held back")
+
+	failed := NotificationEvent{Type: EventFailed, Kind: "Deployment", Namespace: "default", Name: "batch", Reason: "ProgressDeadlineExceeded"}
+	require.NoError(t, r.sendNotifications(ctx, tracker, failed))
+	assert.Len(t, slack.events, 1, "critical notifications are always sent")
+	<-slack.events
+
+	// 트래커 자체 스케줄이 우선하며, 다이제스트로 보류
+	tracker.Spec.Notify.Schedule = &ddukbgv1alpha1.NotifySchedule{
+		QuietHours: []ddukbgv1alpha1.QuietWindow{{Cron: "* * * * *", Duration: &metav1.Duration{Duration: time.Hour}}},
+	}
+	require.NoError(t, r.sendNotifications(ctx, tracker, ready))
+	assert.Empty(t, slack.events)
+
+	held := r.Digests.pending[digestKey{tracker: types.NamespacedName{Namespace: "default", Name: "test-tracker"}, group: quietDigestGroup}]
+	require.NotNil(t, held)
+	assert.Len(t, held.events, 1)
+	assert.Equal(t, map[string]bool{ChannelSlack: true}, held.channels)
+}
+
+func TestQuietHoursHeldInOutbox(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{Schedule: &ddukbgv1alpha1.NotifySchedule{
+				QuietHours: []ddukbgv1alpha1.QuietWindow{{Cron: "* * * * *", Duration: &metav1.Duration{Duration: time.Hour}}},
+			}},
+		},
+		Status: ddukbgv1alpha1.ResourceTrackerStatus{ResourceStatus: map[string]bool{"default/batch": true}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tracker).Build()
+
+	slack := newFakeNotifier()
+	r := &ResourceTrackerReconciler{Client: c, Notifiers: newFakeRegistry(ChannelSlack, slack),
+		Outbox: NewNotificationOutbox(c, c, outboxKey, time.Minute, 1)}
+
+	ctx := context.Background()
+	ready := NotificationEvent{Type: EventReady, Kind: "Deployment", Namespace: "default", Name: "batch"}
+	require.NoError(t, r.sendNotifications(ctx, tracker, ready))
+	assert.Empty(t, slack.events)
+	assert.Nil(t, r.Digests, "held notifications are not kept in memory")
+
+	// 보류된 알림은 해제 시각과 함께 바로 저장됨
+	key := eventID(client.ObjectKeyFromObject(tracker), ready) + "." + ChannelSlack
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, outboxKey, cm))
+	var stored OutboxEntry
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[key]), &stored))
+	require.NotNil(t, stored.HeldUntil)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *stored.HeldUntil, time.Minute)
+
+	// 재시작 후의 컨트롤러가 조용한 시간이 끝나면 다이제스트로 전송
+	restarted := NewNotificationOutbox(c, c, outboxKey, time.Minute, 1)
+	r = &ResourceTrackerReconciler{Client: c, Notifiers: newFakeRegistry(ChannelSlack, slack), Outbox: restarted}
+	restarted.redeliver, restarted.release = r.redeliver, r.releaseHeld
+	restarted.replay(ctx)
+	assert.Empty(t, slack.events, "held until the quiet hours end")
+
+	ended := time.Now().Add(-time.Second)
+	restarted.records[key].entry.HeldUntil = &ended
+	restarted.replay(ctx)
+	require.Len(t, slack.events, 1)
+	digest := <-slack.events
+	assert.Equal(t, EventDigest, digest.Type)
+	require.Len(t, digest.Events, 1)
+	assert.Equal(t, "batch", digest.Events[0].Name)
+	assert.NotNil(t, outboxEntries(t, restarted)[key].CompletedAt)
+
+	restarted.replay(ctx)
+	assert.Empty(t, slack.events, "released only once")
+}
+
+func TestCronSchedulePrevious(t *testing.T) {
+	schedule, err := parseCron("30 3 * * 1-5")
+	require.NoError(t, err)
+
+	// 금요일 12시 기준 직전 실행은 같은 날 03:30
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	prev, ok := schedule.previous(now, now.Add(-72*time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, 10, 16, 3, 30, 0, 0, time.UTC), prev)
+
+	// 월요일 02시 기준으로는 주말을 건너뛴 금요일
+	monday := time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC)
+	prev, ok = schedule.previous(monday, monday.Add(-96*time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, 10, 16, 3, 30, 0, 0, time.UTC), prev)
+
+	_, ok = schedule.previous(now, time.Date(2026, 10, 16, 3, 30, 0, 0, time.UTC))
+	assert.False(t, ok, "the bound is exclusive")
+}
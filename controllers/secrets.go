@@ -0,0 +1,107 @@
+// controllers/secrets.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// secretValue reads the referenced key from a Secret in the given namespace. The
+// manager's client reads Secrets from the API server, since only their metadata
+// is cached for the watch.
+func secretValue(ctx context.Context, c client.Reader, namespace string, ref *ddukbgv1alpha1.SecretKeyRef) (string, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", ref.Name, err)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok || len(value) == 0 {
+		return "", fmt.Errorf("secret %s has no key %q", ref.Name, ref.Key)
+	}
+	return string(value), nil
+}
+
+// referencedSecrets returns the names of the Secrets the tracker's notifiers read
+func referencedSecrets(tracker *ddukbgv1alpha1.ResourceTracker) []string {
+	var names []string
+	notify := tracker.Spec.Notify
+
+	if notify.SlackSecretRef != nil {
+		names = append(names, notify.SlackSecretRef.Name)
+	}
+	if notify.SlackBot != nil {
+		names = append(names, notify.SlackBot.TokenSecretRef.Name)
+	}
+	if notify.TeamsSecretRef != nil {
+		names = append(names, notify.TeamsSecretRef.Name)
+	}
+	if notify.PagerDuty != nil {
+		names = append(names, notify.PagerDuty.RoutingKeySecretRef.Name)
+	}
+	if notify.Opsgenie != nil {
+		names = append(names, notify.Opsgenie.APIKeySecretRef.Name)
+	}
+	if notify.Discord != nil {
+		names = append(names, notify.Discord.URLSecretRef.Name)
+	}
+	if notify.Telegram != nil {
+		names = append(names, notify.Telegram.BotTokenSecretRef.Name)
+	}
+	if notify.Mattermost != nil {
+		names = append(names, notify.Mattermost.URLSecretRef.Name)
+	}
+	if notify.GoogleChat != nil {
+		names = append(names, notify.GoogleChat.URLSecretRef.Name)
+	}
+	if notify.SMTP != nil && notify.SMTP.CredentialsSecret != "" {
+		names = append(names, notify.SMTP.CredentialsSecret)
+	}
+	if notify.CloudEvents != nil && notify.CloudEvents.SinkSecretRef != nil {
+		names = append(names, notify.CloudEvents.SinkSecretRef.Name)
+	}
+	if notify.Webhook != nil {
+		if notify.Webhook.URLSecretRef != nil {
+			names = append(names, notify.Webhook.URLSecretRef.Name)
+		}
+		for _, header := range notify.Webhook.Headers {
+			if header.ValueFrom != nil {
+				names = append(names, header.ValueFrom.Name)
+			}
+		}
+	}
+	return names
+}
+
+// findTrackersForSecret re-enqueues trackers that reference a Secret when it changes,
+// so rotated credentials are picked up. obj only carries the Secret's metadata.
+func (r *ResourceTrackerReconciler) findTrackersForSecret(ctx context.Context, obj client.Object) []ctrl.Request {
+	trackers := &ddukbgv1alpha1.ResourceTrackerList{}
+	if err := r.List(ctx, trackers, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, tracker := range trackers.Items {
+		for _, name := range referencedSecrets(&tracker) {
+			if name == obj.GetName() {
+				requests = append(requests, ctrl.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      tracker.Name,
+						Namespace: tracker.Namespace,
+					},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSlackSecretRef(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack-webhook", Namespace: "default"},
+		Data:       map[string][]byte{"url": []byte("https://hooks.slack.com/services/secret")},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				Slack:          "https://hooks.slack.com/services/plaintext",
+				SlackSecretRef: &ddukbgv1alpha1.SecretKeyRef{Name: "slack-webhook", Key: "url"},
+			},
+		},
+	}
+
+	notifier := &SlackNotifier{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+	}
+
+	webhookURL, err := notifier.webhookURL(context.Background(), tracker)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.slack.com/services/secret", webhookURL)
+
+	// 존재하지 않는 키를 참조하면 오류
+	tracker.Spec.Notify.SlackSecretRef.Key = "missing"
+	_, err = notifier.webhookURL(context.Background(), tracker)
+	assert.Error(t, err)
+	assert.Error(t, notifier.Notify(context.Background(), tracker, NotificationEvent{Message: "test message"}))
+}
+
+func TestFindTrackersForSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	slackTracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				SlackSecretRef: &ddukbgv1alpha1.SecretKeyRef{Name: "notify-secrets", Key: "slack"},
+			},
+		},
+	}
+	emailTracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "email-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				SMTP: &ddukbgv1alpha1.SMTPConfig{CredentialsSecret: "smtp-credentials"},
+			},
+		},
+	}
+	cloudEventsTracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudevents-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				CloudEvents: &ddukbgv1alpha1.CloudEventsConfig{
+					SinkSecretRef: &ddukbgv1alpha1.SecretKeyRef{Name: "broker", Key: "url"},
+				},
+			},
+		},
+	}
+	otherNamespace := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack-tracker", Namespace: "other"},
+		Spec:       slackTracker.Spec,
+	}
+
+	r := &ResourceTrackerReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(slackTracker, emailTracker, cloudEventsTracker,
+			otherNamespace).Build(),
+		Scheme: scheme,
+	}
+
+	// watch는 Secret의 메타데이터만 전달
+	requests := r.findTrackersForSecret(context.Background(), &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: "notify-secrets", Namespace: "default"},
+	})
+	require.Len(t, requests, 1)
+	assert.Equal(t, "slack-tracker", requests[0].Name)
+	assert.Equal(t, "default", requests[0].Namespace)
+
+	requests = r.findTrackersForSecret(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp-credentials", Namespace: "default"},
+	})
+	require.Len(t, requests, 1)
+	assert.Equal(t, "email-tracker", requests[0].Name)
+
+	requests = r.findTrackersForSecret(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "broker", Namespace: "default"},
+	})
+	require.Len(t, requests, 1)
+	assert.Equal(t, "cloudevents-tracker", requests[0].Name)
+
+	assert.Empty(t, r.findTrackersForSecret(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default"},
+	}))
+}
@@ -0,0 +1,233 @@
+// controllers/slack.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// Slack message styles
+const (
+	SlackStyleText   = "text"
+	SlackStyleBlocks = "blocks"
+)
+
+// Attachment colour bars
+const (
+	slackColorReady       = "#2EB67D"
+	slackColorFailed      = "#E01E5A"
+	slackColorProgressing = "#ECB22E"
+)
+
+// SlackMessage is the payload of a Slack incoming webhook.
+// Text is shown in notifications and by clients that cannot render attachments.
+type SlackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []SlackAttachment `json:"attachments,omitempty"`
+}
+
+// SlackAttachment wraps Block Kit blocks so that the message gets a colour bar
+type SlackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit layout block (section, actions or context)
+type SlackBlock struct {
+	Type     string         `json:"type"`
+	Text     *SlackText     `json:"text,omitempty"`
+	Fields   []SlackText    `json:"fields,omitempty"`
+	Elements []SlackElement `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackElement is a Block Kit button or context element
+type SlackElement struct {
+	Type string     `json:"type"`
+	Text *SlackText `json:"text,omitempty"`
+	URL  string     `json:"url,omitempty"`
+}
+
+// SlackNotifier posts notifications to a Slack incoming webhook
+type SlackNotifier struct {
+	// Client reads the Secret referenced by slackSecretRef
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *SlackNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.Slack != "" || tracker.Spec.Notify.SlackSecretRef != nil
+}
+
+// Notify implements Notifier
+func (n *SlackNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	webhookURL, err := n.webhookURL(ctx, tracker)
+	if err != nil {
+		return err
+	}
+
+	attachments := slackAttachments(ctx, tracker, event)
+	if attachments == nil {
+		return postSlackMessage(ctx, webhookURL, event.Message)
+	}
+	return postJSON(ctx, ChannelSlack, webhookURL, SlackMessage{Text: event.Message, Attachments: attachments})
+}
+
+// webhookURL returns the tracker's Slack webhook URL, preferring the Secret reference
+func (n *SlackNotifier) webhookURL(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker) (string, error) {
+	if ref := tracker.Spec.Notify.SlackSecretRef; ref != nil {
+		return secretValue(ctx, n.Client, tracker.Namespace, ref)
+	}
+	return tracker.Spec.Notify.Slack, nil
+}
+
+// postSlackMessage posts the message to a Slack incoming webhook
+func postSlackMessage(ctx context.Context, webhookURL string, message string) error {
+	return postJSON(ctx, ChannelSlack, webhookURL, SlackMessage{Text: message})
+}
+
+// slackAttachments returns the Block Kit attachments for the tracker's Slack style,
+// or nil for the plain text style
+func slackAttachments(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) []SlackAttachment {
+	if tracker.Spec.Notify.SlackStyle != SlackStyleBlocks {
+		return nil
+	}
+
+	dashboardURL, err := dashboardURL(tracker, event)
+	if err != nil {
+		// 잘못된 대시보드 템플릿 때문에 알림 자체를 잃지 않도록 버튼만 생략
+		log.FromContext(ctx).Error(err, "Omitting dashboard button", "tracker", client.ObjectKeyFromObject(tracker))
+	}
+	return slackBlocksMessage(event, dashboardURL).Attachments
+}
+
+// slackBlocksMessage builds a Block Kit message for the event. The plain message is
+// kept as Text so notifications and older clients still show something useful.
+func slackBlocksMessage(event NotificationEvent, dashboardURL string) SlackMessage {
+	fields := []SlackText{
+		{Type: "mrkdwn", Text: "*Namespace*\n" + event.Namespace},
+		{Type: "mrkdwn", Text: fmt.Sprintf("*Replicas*\n%d/%d ready", event.ReadyReplicas, event.TotalReplicas)},
+	}
+	if image := imageChange(event); image != "" {
+		fields = append(fields, SlackText{Type: "mrkdwn", Text: "*Image*\n" + image})
+	}
+	if event.Duration > 0 {
+		fields = append(fields, SlackText{Type: "mrkdwn", Text: "*Rollout duration*\n" + event.Duration.String()})
+	}
+	if event.Reason != "" {
+		fields = append(fields, SlackText{Type: "mrkdwn", Text: "*Reason*\n" + event.Reason})
+	}
+
+	blocks := []SlackBlock{
+		{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: "*" + eventTitle(event) + "*"}},
+		{Type: "section", Fields: fields},
+	}
+	if dashboardURL != "" {
+		blocks = append(blocks, SlackBlock{
+			Type: "actions",
+			Elements: []SlackElement{{
+				Type: "button",
+				Text: &SlackText{Type: "plain_text", Text: "View dashboard"},
+				URL:  dashboardURL,
+			}},
+		})
+	}
+	blocks = append(blocks, SlackBlock{
+		Type: "context",
+		Elements: []SlackElement{{
+			Type: "mrkdwn",
+			Text: &SlackText{Type: "mrkdwn", Text: event.Timestamp.UTC().Format("2006-01-02 15:04:05 MST")},
+		}},
+	})
+
+	return SlackMessage{
+		Text:        event.Message,
+		Attachments: []SlackAttachment{{Color: slackColor(event), Blocks: blocks}},
+	}
+}
+
+// slackColor picks the attachment colour bar for the event
+func slackColor(event NotificationEvent) string {
+	switch event.Type {
+	case EventReady, EventCompleted, EventRolloutPromoted:
+		return slackColorReady
+	case EventFailed, EventRolloutAborted:
+		return slackColorFailed
+	default:
+		return slackColorProgressing
+	}
+}
+
+// eventTitle is a one line summary of the event used as a message heading
+func eventTitle(event NotificationEvent) string {
+	switch event.Type {
+	case EventReady:
+		return fmt.Sprintf("%s %s/%s is now ready", event.Kind, event.Namespace, event.Name)
+	case EventNotReady:
+		return fmt.Sprintf("%s %s/%s is no longer ready", event.Kind, event.Namespace, event.Name)
+	case EventCompleted:
+		return fmt.Sprintf("%s %s/%s completed", event.Kind, event.Namespace, event.Name)
+	case EventFailed:
+		return fmt.Sprintf("%s %s/%s has failed", event.Kind, event.Namespace, event.Name)
+	case EventRecovered:
+		return fmt.Sprintf("%s %s/%s recovered", event.Kind, event.Namespace, event.Name)
+	case EventImageChanged:
+		return fmt.Sprintf("%s %s/%s image changed", event.Kind, event.Namespace, event.Name)
+	case EventScaled:
+		return fmt.Sprintf("%s %s/%s was scaled", event.Kind, event.Namespace, event.Name)
+	case EventDeleted:
+		return fmt.Sprintf("%s %s/%s was deleted", event.Kind, event.Namespace, event.Name)
+	case EventForgotten:
+		return fmt.Sprintf("%s %s/%s is no longer tracked", event.Kind, event.Namespace, event.Name)
+	case EventRolloutStep:
+		return fmt.Sprintf("%s %s/%s is at step %d/%d", event.Kind, event.Namespace, event.Name,
+			event.Rollout.Step, event.Rollout.Steps)
+	case EventRolloutPaused:
+		return fmt.Sprintf("%s %s/%s is paused", event.Kind, event.Namespace, event.Name)
+	case EventAnalysis:
+		return fmt.Sprintf("%s %s/%s analysis %s", event.Kind, event.Namespace, event.Name, event.Reason)
+	case EventRolloutAborted:
+		return fmt.Sprintf("%s %s/%s was aborted", event.Kind, event.Namespace, event.Name)
+	case EventRolloutPromoted:
+		return fmt.Sprintf("%s %s/%s was promoted", event.Kind, event.Namespace, event.Name)
+	case EventDigest:
+		return fmt.Sprintf("%d/%d %ss ready in %s", event.ReadyReplicas, event.TotalReplicas, event.Kind, event.Namespace)
+	case EventSuppressed:
+		return fmt.Sprintf("%d notifications were rate limited", event.Count)
+	default:
+		return fmt.Sprintf("%s %s/%s is progressing", event.Kind, event.Namespace, event.Name)
+	}
+}
+
+// imageChange renders the images as "old → new" when they changed, or the current images otherwise
+func imageChange(event NotificationEvent) string {
+	current := strings.Join(event.Images, ", ")
+	if len(event.PreviousImages) == 0 {
+		return current
+	}
+	return strings.Join(event.PreviousImages, ", ") + " → " + current
+}
+
+// dashboardURL renders the tracker's dashboard URL template for the event
+func dashboardURL(tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) (string, error) {
+	if tracker.Spec.Notify.DashboardURL == "" {
+		return "", nil
+	}
+	url, err := renderEventTemplate("dashboard URL", tracker.Spec.Notify.DashboardURL, event)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(url)), nil
+}
@@ -0,0 +1,225 @@
+// controllers/slack_bot.go
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+const defaultSlackAPIURL = "https://slack.com/api"
+
+// slackPostMessage is the body of a chat.postMessage call
+type slackPostMessage struct {
+	Channel        string            `json:"channel"`
+	Text           string            `json:"text"`
+	ThreadTS       string            `json:"thread_ts,omitempty"`
+	ReplyBroadcast bool              `json:"reply_broadcast,omitempty"`
+	Attachments    []SlackAttachment `json:"attachments,omitempty"`
+}
+
+// slackAPIResponse is the part of a Slack Web API response the notifier reads
+type slackAPIResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	TS    string `json:"ts,omitempty"`
+}
+
+// SlackBotNotifier posts notifications with a Slack bot token. All updates of one
+// rollout, from the first not-ready event of the new revision to ready or failed,
+// are posted as replies to a single parent message, and the thread is recorded in
+// the tracker status so that it survives controller restarts. The thread is
+// dropped once the rollout is ready, or when the resource is deleted or forgotten.
+type SlackBotNotifier struct {
+	// Client reads the bot token Secret and records threads in the tracker status
+	Client client.Client
+
+	// APIURL is the Slack Web API base URL
+	APIURL string
+
+	// locks serialize the deliveries of each thread so that two workers never start
+	// two threads for one rollout
+	locks keyedMutex
+
+	mu      sync.Mutex
+	threads map[string]ddukbgv1alpha1.SlackThread
+}
+
+// NewSlackBotNotifier creates a notifier using the public Slack Web API
+func NewSlackBotNotifier(c client.Client) *SlackBotNotifier {
+	return &SlackBotNotifier{
+		Client:  c,
+		APIURL:  defaultSlackAPIURL,
+		threads: make(map[string]ddukbgv1alpha1.SlackThread),
+	}
+}
+
+// Enabled implements Notifier
+func (n *SlackBotNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.SlackBot != nil
+}
+
+// Notify implements Notifier
+func (n *SlackBotNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	key := fmt.Sprintf("%s/%s", event.Namespace, event.Name)
+	unlock := n.locks.Lock(threadCacheKey(tracker, key))
+	defer unlock()
+
+	// 더 이상 추적하지 않는 리소스는 게시하지 않고 스레드만 정리
+	if event.Type == EventForgotten {
+		return n.dropThread(ctx, tracker, key)
+	}
+
+	cfg := tracker.Spec.Notify.SlackBot
+	token, err := secretValue(ctx, n.Client, tracker.Namespace, &cfg.TokenSecretRef)
+	if err != nil {
+		return err
+	}
+
+	msg := slackPostMessage{
+		Channel:     cfg.Channel,
+		Text:        event.Message,
+		Attachments: slackAttachments(ctx, tracker, event),
+	}
+
+	// Pod처럼 리비전이 없는 리소스는 스레드 없이 채널에 바로 게시
+	if event.Revision == "" {
+		// 진행 상황을 남길 스레드가 없으므로 not-ready는 게시하지 않음
+		if event.Type == EventNotReady {
+			return nil
+		}
+		if _, err := n.postMessage(ctx, token, msg); err != nil {
+			return err
+		}
+		if event.Type == EventDeleted {
+			return n.dropThread(ctx, tracker, key)
+		}
+		return nil
+	}
+
+	thread := n.thread(tracker, key)
+	if thread.TS == "" || thread.Revision != event.Revision || thread.Channel != cfg.Channel {
+		ts, err := n.postMessage(ctx, token, slackPostMessage{Channel: cfg.Channel, Text: rolloutTitle(event)})
+		if err != nil {
+			return err
+		}
+		thread = ddukbgv1alpha1.SlackThread{Revision: event.Revision, Channel: cfg.Channel, TS: ts}
+		n.mu.Lock()
+		n.threads[threadCacheKey(tracker, key)] = thread
+		n.mu.Unlock()
+
+		if err := n.saveThread(ctx, client.ObjectKeyFromObject(tracker), key, thread); err != nil {
+			// 메모리에 기록된 스레드로 계속 진행하고, 재시작 후에만 새 스레드가 생김
+			log.FromContext(ctx).Error(err, "Failed to record Slack thread", "resource", key)
+		}
+	}
+
+	msg.ThreadTS = thread.TS
+	// 실패는 스레드를 열지 않아도 보이도록 채널에도 게시
+	msg.ReplyBroadcast = event.Type == EventFailed
+	if _, err := n.postMessage(ctx, token, msg); err != nil {
+		return err
+	}
+	// 롤아웃이 끝나면 스레드를 정리. 실패한 롤아웃은 이어서 Ready가 될 수 있으므로 스레드를 유지
+	if event.Type == EventReady {
+		return n.dropThread(ctx, tracker, key)
+	}
+	return nil
+}
+
+// thread returns the known thread of the resource, from memory or the tracker status
+func (n *SlackBotNotifier) thread(tracker *ddukbgv1alpha1.ResourceTracker, key string) ddukbgv1alpha1.SlackThread {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.threads == nil {
+		n.threads = make(map[string]ddukbgv1alpha1.SlackThread)
+	}
+	if thread, ok := n.threads[threadCacheKey(tracker, key)]; ok {
+		return thread
+	}
+	return tracker.Status.SlackThreads[key]
+}
+
+// dropThread forgets the thread of the resource, in memory and in the tracker status.
+// The thread's lock must be held.
+func (n *SlackBotNotifier) dropThread(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, key string) error {
+	n.mu.Lock()
+	_, cached := n.threads[threadCacheKey(tracker, key)]
+	delete(n.threads, threadCacheKey(tracker, key))
+	n.mu.Unlock()
+
+	if _, recorded := tracker.Status.SlackThreads[key]; !cached && !recorded {
+		return nil
+	}
+	return updateNotifierStatus(ctx, n.Client, client.ObjectKeyFromObject(tracker), func(status *ddukbgv1alpha1.ResourceTrackerStatus) {
+		delete(status.SlackThreads, key)
+	})
+}
+
+// saveThread records the thread in the status of the latest tracker object
+func (n *SlackBotNotifier) saveThread(ctx context.Context, trackerKey types.NamespacedName, key string,
+	thread ddukbgv1alpha1.SlackThread) error {
+	return updateNotifierStatus(ctx, n.Client, trackerKey, func(status *ddukbgv1alpha1.ResourceTrackerStatus) {
+		if status.SlackThreads == nil {
+			status.SlackThreads = make(map[string]ddukbgv1alpha1.SlackThread)
+		}
+		status.SlackThreads[key] = thread
+	})
+}
+
+// postMessage calls chat.postMessage and returns the ts of the posted message
+func (n *SlackBotNotifier) postMessage(ctx context.Context, token string, msg slackPostMessage) (string, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s message: %v", ChannelSlackBot, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.APIURL+"/chat.postMessage", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s request: %v", ChannelSlackBot, err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := notificationHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send %s notification: %w", ChannelSlackBot, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", newDeliveryError(ChannelSlackBot, resp)
+	}
+
+	// Slack Web API는 대부분의 오류를 200 응답의 ok=false로 알려줌
+	var result slackAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode %s response: %v", ChannelSlackBot, err)
+	}
+	if !result.OK {
+		return "", &deliveryError{Channel: ChannelSlackBot, StatusCode: resp.StatusCode, Reason: result.Error}
+	}
+	return result.TS, nil
+}
+
+// threadCacheKey identifies a resource's thread across trackers
+func threadCacheKey(tracker *ddukbgv1alpha1.ResourceTracker, key string) string {
+	return tracker.Namespace + "/" + tracker.Name + "|" + key
+}
+
+// rolloutTitle is the parent message of a rollout thread
+func rolloutTitle(event NotificationEvent) string {
+	return fmt.Sprintf("*Rollout of %s %s/%s (revision %s)*\n> Images: %s",
+		event.Kind, event.Namespace, event.Name, event.Revision, strings.Join(event.Images, ", "))
+}
@@ -0,0 +1,228 @@
+// controllers/targets.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// validateTarget rejects targets that would silently track the wrong resources
+func validateTarget(target ddukbgv1alpha1.ResourceTarget) error {
+	if _, err := metav1.LabelSelectorAsSelector(target.Selector); err != nil {
+		return fmt.Errorf("invalid target selector: %w", err)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(target.NamespaceSelector); err != nil {
+		return fmt.Errorf("invalid target namespaceSelector: %w", err)
+	}
+	if target.Namespace == "" && len(target.Namespaces) == 0 && target.NamespaceSelector == nil && !target.AllNamespaces {
+		return fmt.Errorf("one of namespace, namespaces, namespaceSelector or allNamespaces is required")
+	}
+	return nil
+}
+
+// singleResource reports whether the target is one named resource in one namespace
+func singleResource(target ddukbgv1alpha1.ResourceTarget) bool {
+	return target.Name != "" && target.Namespace != "" && len(target.Namespaces) == 0 &&
+		target.NamespaceSelector == nil && !target.AllNamespaces
+}
+
+// targetSelects reports whether resources with the labels match the target's
+// selector. Targets without a selector select every resource.
+func targetSelects(target ddukbgv1alpha1.ResourceTarget, labels map[string]string) bool {
+	if target.Selector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(target.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(k8slabels.Set(labels))
+}
+
+// targetNamespaces returns the namespaces the target monitors: namespace,
+// namespaces and the namespaces matching namespaceSelector. all is true when the
+// target monitors every namespace.
+func (r *ResourceTrackerReconciler) targetNamespaces(ctx context.Context,
+	target ddukbgv1alpha1.ResourceTarget) (namespaces []string, all bool, err error) {
+	if target.AllNamespaces {
+		return nil, true, nil
+	}
+
+	if target.Namespace != "" {
+		namespaces = append(namespaces, target.Namespace)
+	}
+	namespaces = append(namespaces, target.Namespaces...)
+	if target.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(target.NamespaceSelector)
+		if err != nil {
+			return nil, false, err
+		}
+		nsList := &corev1.NamespaceList{}
+		if err := r.List(ctx, nsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, false, err
+		}
+		for _, ns := range nsList.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces), false, nil
+}
+
+// targetInNamespace reports whether the target monitors resources in the namespace
+func (r *ResourceTrackerReconciler) targetInNamespace(ctx context.Context, target ddukbgv1alpha1.ResourceTarget,
+	namespace string) bool {
+	if target.AllNamespaces || target.Namespace == namespace || slices.Contains(target.Namespaces, namespace) {
+		return true
+	}
+	if target.NamespaceSelector == nil {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(target.NamespaceSelector)
+	if err != nil {
+		return false
+	}
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to get namespace", "namespace", namespace, "error", err)
+		return false
+	}
+	return selector.Matches(k8slabels.Set(ns.Labels))
+}
+
+// listTargets lists the resources of the target's namespaces that match its name,
+// if set, or else its selector. The selectors are validated by Reconcile.
+func (r *ResourceTrackerReconciler) listTargets(ctx context.Context, target ddukbgv1alpha1.ResourceTarget,
+	list client.ObjectList) error {
+	var opts []client.ListOption
+	if target.Name == "" && target.Selector != nil {
+		if selector, err := metav1.LabelSelectorAsSelector(target.Selector); err == nil {
+			opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+		}
+	}
+	if err := r.listInNamespaces(ctx, target, list, opts...); err != nil {
+		return err
+	}
+	if target.Name == "" {
+		return nil
+	}
+
+	// 여러 네임스페이스에 있는 같은 이름의 리소스만 남김
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	named := items[:0]
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok && obj.GetName() == target.Name {
+			named = append(named, item)
+		}
+	}
+	return meta.SetList(list, named)
+}
+
+// listInNamespaces lists resources across the target's namespaces, one namespace
+// at a time unless the target monitors every namespace
+func (r *ResourceTrackerReconciler) listInNamespaces(ctx context.Context, target ddukbgv1alpha1.ResourceTarget,
+	list client.ObjectList, opts ...client.ListOption) error {
+	namespaces, all, err := r.targetNamespaces(ctx, target)
+	if err != nil {
+		return err
+	}
+	if all {
+		return r.List(ctx, list, opts...)
+	}
+	if len(namespaces) == 1 {
+		return r.List(ctx, list, append(opts, client.InNamespace(namespaces[0]))...)
+	}
+
+	var items []runtime.Object
+	for _, namespace := range namespaces {
+		page := list.DeepCopyObject().(client.ObjectList)
+		if err := r.List(ctx, page, append(opts, client.InNamespace(namespace))...); err != nil {
+			return err
+		}
+		pageItems, err := meta.ExtractList(page)
+		if err != nil {
+			return err
+		}
+		items = append(items, pageItems...)
+	}
+	return meta.SetList(list, items)
+}
+
+// describeNamespaces summarizes the target's namespaces for digests
+func describeNamespaces(target ddukbgv1alpha1.ResourceTarget) string {
+	if target.AllNamespaces {
+		return "all namespaces"
+	}
+	var parts []string
+	if target.Namespace != "" {
+		parts = append(parts, target.Namespace)
+	}
+	parts = append(parts, target.Namespaces...)
+	slices.Sort(parts)
+	parts = slices.Compact(parts)
+	if target.NamespaceSelector != nil {
+		parts = append(parts, "namespaces matching "+metav1.FormatLabelSelector(target.NamespaceSelector))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// findTrackersForNamespace re-evaluates trackers whose namespaces may change when
+// the namespace is created or relabeled
+func (r *ResourceTrackerReconciler) findTrackersForNamespace(ctx context.Context, obj client.Object) []ctrl.Request {
+	trackers := &ddukbgv1alpha1.ResourceTrackerList{}
+	if err := r.List(ctx, trackers); err != nil {
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, tracker := range trackers.Items {
+		target := tracker.Spec.Target
+		if target.NamespaceSelector != nil || target.AllNamespaces || slices.Contains(target.Namespaces, obj.GetName()) {
+			requests = append(requests, ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: tracker.Name, Namespace: tracker.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// targetExists reports whether a resource of the target's kind still exists. It
+// reads an unstructured object so that no informer is started for the check.
+func (r *ResourceTrackerReconciler) targetExists(ctx context.Context, target ddukbgv1alpha1.ResourceTarget,
+	key types.NamespacedName) (bool, error) {
+	apiVersion := target.APIVersion
+	if apiVersion == "" {
+		apiVersion = builtinKinds[target.Kind]
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(target.Kind)
+	if err := r.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
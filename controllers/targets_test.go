@@ -0,0 +1,343 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// teamDeployment returns a ready Deployment owned by the given team
+func teamDeployment(name, team string) *appsv1.Deployment {
+	return namespacedDeployment("default", name, team)
+}
+
+func namespacedDeployment(namespace, name, team string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"team": team}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: name + ":1.0"}}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+}
+
+func TestReconcileSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	ctx := context.Background()
+	slack := newFakeNotifier()
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{
+				Kind:      "Deployment",
+				Namespace: "default",
+				Selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			},
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: "https://hooks.slack.com/test"},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker, teamDeployment("checkout", "payments"), teamDeployment("search", "discovery")).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "payments", Namespace: "default"}}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	ready := <-slack.events
+	assert.Equal(t, EventReady, ready.Type)
+	assert.Equal(t, "checkout", ready.Name)
+	assert.Empty(t, slack.events)
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Equal(t, map[string]bool{"default/checkout": true}, updated.Status.ResourceStatus)
+
+	// 다른 팀으로 옮겨진 리소스는 삭제 알림 없이 추적에서 제외
+	deploy := &appsv1.Deployment{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "checkout", Namespace: "default"}, deploy))
+	deploy.Labels["team"] = "discovery"
+	require.NoError(t, c.Update(ctx, deploy))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, slack.events)
+
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Empty(t, updated.Status.ResourceStatus)
+	assert.Empty(t, updated.Status.ResourceStates)
+}
+
+func TestReconcileSelectorResolvesIncident(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	ctx := context.Background()
+	slack := newFakeNotifier()
+	pagerDuty := newFakeNotifier()
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, slack)
+	registry.Register(ChannelPagerDuty, pagerDuty)
+
+	// 실패해서 인시던트가 열린 채로 다른 팀으로 옮겨진 리소스
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{
+				Kind:      "Deployment",
+				Namespace: "default",
+				Selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			},
+		},
+		Status: ddukbgv1alpha1.ResourceTrackerStatus{
+			ResourceStatus:     map[string]bool{"default/checkout": false},
+			TransitionTimes:    map[string]metav1.Time{"default/checkout": metav1.Now()},
+			Failures:           map[string]string{"default/checkout": "ProgressDeadlineExceeded"},
+			PagerDutyIncidents: map[string]string{"default/checkout": "k8s-deploy-watcher/default/payments/default/checkout"},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker, teamDeployment("checkout", "discovery")).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: registry,
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "payments", Namespace: "default"}}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, pagerDuty.events, 1)
+	forgotten := <-pagerDuty.events
+	assert.Equal(t, EventForgotten, forgotten.Type)
+	assert.Equal(t, "checkout", forgotten.Name)
+	assert.Equal(t, "ProgressDeadlineExceeded", forgotten.Reason)
+	assert.Empty(t, slack.events, "only incident channels are told")
+
+	// PagerDuty가 설정되지 않은 트래커는 인시던트 기록을 바로 지움
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Empty(t, updated.Status.Failures)
+	assert.Empty(t, updated.Status.PagerDutyIncidents)
+}
+
+func TestReconcileInvalidSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{
+				Kind:      "Deployment",
+				Namespace: "default",
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: "Equals", Values: []string{"payments"}},
+				}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &ResourceTrackerReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+	// 잘못된 스펙은 재시도하지 않고 상태와 Warning 이벤트로 알림
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "payments", Namespace: "default"}}
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning InvalidTarget invalid target selector")
+
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.False(t, updated.Status.Ready)
+	assert.Contains(t, updated.Status.Message, "Invalid target: invalid target selector")
+
+	// 같은 오류로 다시 Reconcile되어도 이벤트를 반복하지 않음
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestTargetSelects(t *testing.T) {
+	target := ddukbgv1alpha1.ResourceTarget{Kind: "Deployment", Namespace: "default"}
+	assert.True(t, targetSelects(target, nil))
+
+	target.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"team": "payments"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"batch"}},
+		},
+	}
+	assert.True(t, targetSelects(target, map[string]string{"team": "payments", "tier": "web"}))
+	assert.False(t, targetSelects(target, map[string]string{"team": "payments", "tier": "batch"}))
+	assert.False(t, targetSelects(target, map[string]string{"team": "discovery"}))
+	assert.False(t, targetSelects(target, nil))
+}
+
+func environment(name, app string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"app": app}}}
+}
+
+func TestReconcileNamespaceSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	ctx := context.Background()
+	slack := newFakeNotifier()
+
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{
+				Kind:              "Deployment",
+				Name:              "api",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "pay"}},
+			},
+			Notify: ddukbgv1alpha1.NotifyConfig{Slack: "https://hooks.slack.com/test"},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tracker,
+			environment("pay-dev", "pay"), environment("pay-stg", "pay"), environment("search", "search"),
+			namespacedDeployment("pay-dev", "api", "payments"), namespacedDeployment("pay-stg", "api", "payments"),
+			namespacedDeployment("pay-stg", "worker", "payments"), namespacedDeployment("search", "api", "discovery")).
+		WithStatusSubresource(&ddukbgv1alpha1.ResourceTracker{}).
+		Build()
+	r := &ResourceTrackerReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		Notifiers: newFakeRegistry(ChannelSlack, slack),
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "payments", Namespace: "default"}}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	updated := &ddukbgv1alpha1.ResourceTracker{}
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Equal(t, map[string]bool{"pay-dev/api": true, "pay-stg/api": true}, updated.Status.ResourceStatus)
+	assert.Len(t, slack.events, 2)
+	for len(slack.events) > 0 {
+		<-slack.events
+	}
+
+	// 레이블이 바뀐 네임스페이스의 리소스는 삭제 알림 없이 추적에서 제외
+	stg := &corev1.Namespace{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "pay-stg"}, stg))
+	stg.Labels["app"] = "archived"
+	require.NoError(t, c.Update(ctx, stg))
+	assert.Equal(t, []reconcile.Request{req}, r.findTrackersForNamespace(ctx, stg))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, slack.events)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, updated))
+	assert.Equal(t, map[string]bool{"pay-dev/api": true}, updated.Status.ResourceStatus)
+
+	// 새로 생긴 네임스페이스도 추적
+	require.NoError(t, c.Create(ctx, environment("pay-prd", "pay")))
+	require.NoError(t, c.Create(ctx, namespacedDeployment("pay-prd", "api", "payments")))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	ready := <-slack.events
+	assert.Equal(t, EventReady, ready.Type)
+	assert.Equal(t, "pay-prd", ready.Namespace)
+}
+
+func TestTargetNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	r := &ResourceTrackerReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(environment("pay-dev", "pay"), environment("pay-stg", "pay"), environment("search", "search")).
+			Build(),
+		Scheme: scheme,
+	}
+	paySelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "pay"}}
+
+	tests := []struct {
+		name   string
+		target ddukbgv1alpha1.ResourceTarget
+		want   []string
+		all    bool
+		label  string
+	}{
+		{"namespace", ddukbgv1alpha1.ResourceTarget{Namespace: "default"}, []string{"default"}, false, "default"},
+		{"namespaces", ddukbgv1alpha1.ResourceTarget{Namespace: "pay-dev", Namespaces: []string{"pay-stg", "pay-dev"}},
+			[]string{"pay-dev", "pay-stg"}, false, "pay-dev, pay-stg"},
+		{"namespace selector", ddukbgv1alpha1.ResourceTarget{Namespaces: []string{"search"}, NamespaceSelector: paySelector},
+			[]string{"pay-dev", "pay-stg", "search"}, false, "search, namespaces matching app=pay"},
+		{"all namespaces", ddukbgv1alpha1.ResourceTarget{Namespace: "default", AllNamespaces: true}, nil, true, "all namespaces"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespaces, all, err := r.targetNamespaces(context.Background(), tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, namespaces)
+			assert.Equal(t, tt.all, all)
+			assert.Equal(t, tt.label, describeNamespaces(tt.target))
+
+			for _, namespace := range []string{"default", "pay-dev", "pay-stg", "search"} {
+				assert.Equal(t, tt.all || slices.Contains(tt.want, namespace),
+					r.targetInNamespace(context.Background(), tt.target, namespace), namespace)
+			}
+		})
+	}
+}
+
+func TestValidateTarget(t *testing.T) {
+	assert.NoError(t, validateTarget(ddukbgv1alpha1.ResourceTarget{Kind: "Pod", AllNamespaces: true}))
+	assert.ErrorContains(t, validateTarget(ddukbgv1alpha1.ResourceTarget{Kind: "Pod"}), "allNamespaces is required")
+	assert.ErrorContains(t, validateTarget(ddukbgv1alpha1.ResourceTarget{
+		Kind: "Pod",
+		NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: metav1.LabelSelectorOpIn},
+		}},
+	}), "invalid target namespaceSelector")
+}
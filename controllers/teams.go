@@ -0,0 +1,138 @@
+// controllers/teams.go
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// TeamsMessage is the payload of a Teams incoming webhook carrying one Adaptive Card
+type TeamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment wraps an Adaptive Card
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard is the subset of the Adaptive Card schema the notifier uses
+type AdaptiveCard struct {
+	Schema  string               `json:"$schema"`
+	Type    string               `json:"type"`
+	Version string               `json:"version"`
+	Body    []AdaptiveCardItem   `json:"body"`
+	Actions []AdaptiveCardAction `json:"actions,omitempty"`
+}
+
+// AdaptiveCardItem is a TextBlock or FactSet element of a card body
+type AdaptiveCardItem struct {
+	Type   string             `json:"type"`
+	Text   string             `json:"text,omitempty"`
+	Weight string             `json:"weight,omitempty"`
+	Size   string             `json:"size,omitempty"`
+	Color  string             `json:"color,omitempty"`
+	Wrap   bool               `json:"wrap,omitempty"`
+	Facts  []AdaptiveCardFact `json:"facts,omitempty"`
+}
+
+// AdaptiveCardFact is a title/value pair of a FactSet
+type AdaptiveCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// AdaptiveCardAction is an Action.OpenUrl button
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// TeamsNotifier posts Adaptive Card notifications to a Microsoft Teams webhook
+type TeamsNotifier struct {
+	// Client reads the Secret referenced by teamsSecretRef
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *TeamsNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.Teams != "" || tracker.Spec.Notify.TeamsSecretRef != nil
+}
+
+// Notify implements Notifier
+func (n *TeamsNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	webhookURL := tracker.Spec.Notify.Teams
+	if ref := tracker.Spec.Notify.TeamsSecretRef; ref != nil {
+		url, err := secretValue(ctx, n.Client, tracker.Namespace, ref)
+		if err != nil {
+			return err
+		}
+		webhookURL = url
+	}
+
+	dashboardURL, err := dashboardURL(tracker, event)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Omitting dashboard button", "tracker", client.ObjectKeyFromObject(tracker))
+	}
+	return postJSON(ctx, ChannelTeams, webhookURL, teamsMessage(event, dashboardURL))
+}
+
+// teamsMessage builds an Adaptive Card with the same details as the Slack message
+func teamsMessage(event NotificationEvent, dashboardURL string) TeamsMessage {
+	facts := []AdaptiveCardFact{
+		{Title: "Namespace", Value: event.Namespace},
+		{Title: "Status", Value: event.Phase},
+		{Title: "Replicas", Value: fmt.Sprintf("%d/%d ready", event.ReadyReplicas, event.TotalReplicas)},
+	}
+	if image := imageChange(event); image != "" {
+		facts = append(facts, AdaptiveCardFact{Title: "Image", Value: image})
+	}
+	if event.Duration > 0 {
+		facts = append(facts, AdaptiveCardFact{Title: "Rollout duration", Value: event.Duration.String()})
+	}
+	if event.Reason != "" {
+		facts = append(facts, AdaptiveCardFact{Title: "Reason", Value: event.Reason})
+	}
+
+	card := AdaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body: []AdaptiveCardItem{
+			{Type: "TextBlock", Text: eventTitle(event), Weight: "Bolder", Size: "Medium", Color: teamsColor(event), Wrap: true},
+			{Type: "FactSet", Facts: facts},
+		},
+	}
+	if dashboardURL != "" {
+		card.Actions = []AdaptiveCardAction{{Type: "Action.OpenUrl", Title: "View dashboard", URL: dashboardURL}}
+	}
+
+	return TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		}},
+	}
+}
+
+// teamsColor maps the event to an Adaptive Card text colour
+func teamsColor(event NotificationEvent) string {
+	switch event.Type {
+	case EventReady, EventCompleted, EventRolloutPromoted:
+		return "Good"
+	case EventFailed, EventRolloutAborted:
+		return "Attention"
+	default:
+		return "Warning"
+	}
+}
@@ -0,0 +1,58 @@
+// controllers/telegram.go
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+const (
+	defaultTelegramAPIURL = "https://api.telegram.org"
+	// telegramTextLimit is the maximum message length of sendMessage
+	telegramTextLimit = 4096
+)
+
+// TelegramMessage is the body of a Bot API sendMessage call.
+// The message is sent without parse_mode so that resource names need no escaping.
+type TelegramMessage struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+// TelegramNotifier sends notifications to a Telegram chat through a bot
+type TelegramNotifier struct {
+	// Client reads the Secret holding the bot token
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *TelegramNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.Telegram != nil
+}
+
+// Notify implements Notifier
+func (n *TelegramNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	cfg := tracker.Spec.Notify.Telegram
+	token, err := secretValue(ctx, n.Client, tracker.Namespace, &cfg.BotTokenSecretRef)
+	if err != nil {
+		return err
+	}
+
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultTelegramAPIURL
+	}
+
+	msg := TelegramMessage{
+		ChatID:                cfg.ChatID,
+		Text:                  truncate(event.Message, telegramTextLimit),
+		DisableWebPagePreview: true,
+	}
+	return postJSON(ctx, ChannelTelegram, strings.TrimSuffix(apiURL, "/")+"/bot"+token+"/sendMessage", msg)
+}
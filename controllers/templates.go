@@ -0,0 +1,261 @@
+// controllers/templates.go
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// templateFuncs are available in message templates, webhook bodies and dashboard URLs
+var templateFuncs = template.FuncMap{
+	// json encodes a value, e.g. {{ json .Name }} renders a quoted JSON string
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	// default returns def when value is empty, e.g. {{ .Reason | default "unknown" }}
+	"default": func(def, value string) string {
+		if value == "" {
+			return def
+		}
+		return value
+	},
+	// truncate shortens a string to at most n bytes, e.g. {{ .Message | truncate 200 }}
+	"truncate": func(n int, s string) string {
+		return truncate(s, n)
+	},
+	// duration formats a duration rounded to seconds, e.g. "1m30s"
+	"duration": func(d time.Duration) string {
+		return d.Round(time.Second).String()
+	},
+	// since returns the time elapsed since t
+	"since": func(t time.Time) time.Duration {
+		return time.Since(t)
+	},
+	// list joins names as an English list, e.g. {{ list .Progressing }} renders "api, web and worker"
+	"list":        listNames,
+	"imageName":   imageName,
+	"imageTag":    imageTag,
+	"imageDigest": imageDigest,
+}
+
+// defaultMessageTemplates are the built-in message of each event type
+var defaultMessageTemplates = map[string]string{
+	EventReady: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} is now ready
+> Namespace: {{ .Namespace }}
+> Status: Running
+{{ if eq .Kind "Pod" }}> Phase: {{ .Phase }}{{ else }}> Replicas: {{ .ReadyReplicas }}/{{ .TotalReplicas }} ready{{ end }}`,
+
+	EventNotReady: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} is no longer ready
+> Namespace: {{ .Namespace }}
+{{ if eq .Kind "Pod" }}> Phase: {{ .Phase }}{{ else }}> Replicas: {{ .ReadyReplicas }}/{{ .TotalReplicas }} ready{{ end }}`,
+
+	EventCompleted: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} completed
+> Namespace: {{ .Namespace }}
+> Duration: {{ .Duration | duration }}
+{{- with .Job }}
+> Completions: {{ .Succeeded }}/{{ .Completions }} (parallelism {{ .Parallelism }})
+{{- with .CronJob }}
+> CronJob: {{ . }}{{ end }}{{ end }}`,
+
+	EventFailed: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} has failed
+> Namespace: {{ .Namespace }}
+> Status: Failed
+> Reason: {{ .Reason }}
+> Message: {{ .Detail }}`,
+
+	EventRecovered: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} recovered
+> Namespace: {{ .Namespace }}
+> Recovered from: {{ .Reason }}`,
+
+	EventImageChanged: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} image changed
+> Namespace: {{ .Namespace }}
+> Image: {{ join .PreviousImages ", " }} → {{ join .Images ", " }}`,
+
+	EventScaled: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} was scaled
+> Namespace: {{ .Namespace }}
+> Replicas: {{ .PreviousReplicas }} → {{ .TotalReplicas }}`,
+
+	EventDeleted: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} was deleted
+> Namespace: {{ .Namespace }}`,
+
+	EventRolloutStep: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} is at step {{ .Rollout.Step }}/{{ .Rollout.Steps }}
+> Namespace: {{ .Namespace }}
+> Canary weight: {{ .Rollout.CanaryWeight }}%`,
+
+	EventRolloutPaused: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} is paused
+> Namespace: {{ .Namespace }}
+> Reason: {{ join .Rollout.PauseReasons ", " }}
+{{- if .Rollout.Steps }}
+> Step: {{ .Rollout.Step }}/{{ .Rollout.Steps }} (canary weight {{ .Rollout.CanaryWeight }}%){{ end }}`,
+
+	EventAnalysis: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} analysis {{ .Reason }}
+> Namespace: {{ .Namespace }}
+{{- with .Rollout.Analysis }}
+> AnalysisRun: {{ .Name }}{{ with .Message }}
+> Message: {{ . }}{{ end }}{{ end }}`,
+
+	EventRolloutAborted: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} was aborted
+> Namespace: {{ .Namespace }}
+> Message: {{ .Detail }}`,
+
+	EventRolloutPromoted: `{{ .Kind }} {{ .Namespace }}/{{ .Name }} was promoted
+> Namespace: {{ .Namespace }}
+> Revision: {{ .Revision }}
+> Image: {{ join .Images ", " }}`,
+
+	EventDigest: `{{ .ReadyReplicas }}/{{ .TotalReplicas }} {{ .Kind }}s ready in {{ .Namespace }}
+{{- with .Progressing }}; {{ list . }} still progressing{{ end }}
+{{- range .Events }}
+> {{ .Name }} {{ if eq .Type "ready" }}became ready
+{{- else if eq .Type "not-ready" }}is no longer ready
+{{- else if eq .Type "completed" }}completed in {{ .Duration | duration }}
+{{- else if eq .Type "failed" }}failed: {{ .Reason }}
+{{- else if eq .Type "image-changed" }}image changed to {{ join .Images ", " }}
+{{- else if eq .Type "scaled" }}scaled {{ .PreviousReplicas }} → {{ .TotalReplicas }}
+{{- else if eq .Type "deleted" }}was deleted
+{{- else if eq .Type "rollout-step" }}is at step {{ .Rollout.Step }}/{{ .Rollout.Steps }}
+{{- else if eq .Type "rollout-paused" }}paused: {{ join .Rollout.PauseReasons ", " }}
+{{- else if eq .Type "analysis" }}analysis {{ .Reason }}
+{{- else if eq .Type "rollout-aborted" }}was aborted
+{{- else if eq .Type "rollout-promoted" }}was promoted
+{{- else }}{{ .Type }}{{ end }}
+{{- end }}`,
+}
+
+// defaultTemplates holds defaultMessageTemplates parsed once, keyed by event type
+var defaultTemplates = func() *template.Template {
+	root := template.New("messages").Funcs(templateFuncs)
+	for eventType, text := range defaultMessageTemplates {
+		template.Must(root.New(eventType).Parse(text))
+	}
+	return root
+}()
+
+// renderMessage renders the human readable message of the event with the tracker's
+// template for the event type, falling back to the built-in message
+func renderMessage(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) string {
+	if text := customTemplate(tracker.Spec.Notify.Templates, event.Type); text != "" {
+		msg, err := renderEventTemplate("templates."+event.Type, text, event)
+		if err == nil {
+			return string(msg)
+		}
+		log.FromContext(ctx).Error(err, "Falling back to the default message", "tracker", client.ObjectKeyFromObject(tracker))
+	}
+
+	var buf bytes.Buffer
+	if err := defaultTemplates.ExecuteTemplate(&buf, event.Type, event); err != nil {
+		return eventTitle(event)
+	}
+	return buf.String()
+}
+
+// customTemplate returns the tracker's template for the event type, if any
+func customTemplate(templates *ddukbgv1alpha1.NotificationTemplates, eventType string) string {
+	if templates == nil {
+		return ""
+	}
+	switch eventType {
+	case EventReady:
+		return templates.Ready
+	case EventNotReady:
+		return templates.NotReady
+	case EventCompleted:
+		return templates.Completed
+	case EventFailed:
+		return templates.Failed
+	case EventRecovered:
+		return templates.Recovered
+	case EventImageChanged:
+		return templates.ImageChanged
+	case EventScaled:
+		return templates.Scaled
+	case EventDeleted:
+		return templates.Deleted
+	case EventRolloutStep:
+		return templates.RolloutStep
+	case EventRolloutPaused:
+		return templates.RolloutPaused
+	case EventAnalysis:
+		return templates.Analysis
+	case EventRolloutAborted:
+		return templates.RolloutAborted
+	case EventRolloutPromoted:
+		return templates.RolloutPromoted
+	case EventDigest:
+		return templates.Digest
+	}
+	return ""
+}
+
+// renderEventTemplate executes a user supplied text/template over the event.
+// Unknown fields are an error so that typos do not silently render empty values.
+func renderEventTemplate(name, text string, event NotificationEvent) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %v", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// splitImage splits an image reference into repository, tag and digest
+func splitImage(image string) (name, tag, digest string) {
+	name = image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, digest = name[:i], name[i+1:]
+	}
+	// 레지스트리 포트(registry:5000/app)와 태그를 구분하기 위해 마지막 '/' 이후만 확인
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	return name, tag, digest
+}
+
+// imageName returns the repository of an image, e.g. "ghcr.io/org/app" for "ghcr.io/org/app:1.2"
+func imageName(image string) string {
+	name, _, _ := splitImage(image)
+	return name
+}
+
+// imageTag returns the tag of an image, "latest" when it has neither a tag nor a digest
+func imageTag(image string) string {
+	_, tag, digest := splitImage(image)
+	if tag == "" && digest == "" {
+		return "latest"
+	}
+	return tag
+}
+
+// imageDigest returns the digest of an image, e.g. "sha256:..."
+func imageDigest(image string) string {
+	_, _, digest := splitImage(image)
+	return digest
+}
+
+// listNames implements the list template function
+func listNames(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMessage(t *testing.T) {
+	ctx := context.Background()
+	tracker := &ddukbgv1alpha1.ResourceTracker{}
+
+	deployment := NotificationEvent{
+		Type:          EventReady,
+		Kind:          "Deployment",
+		Namespace:     "default",
+		Name:          "test-app",
+		ReadyReplicas: 3,
+		TotalReplicas: 3,
+	}
+	assert.Equal(t, readyMessage("Deployment", "default", "test-app", 3, 3), renderMessage(ctx, tracker, deployment))
+
+	pod := NotificationEvent{Type: EventReady, Kind: "Pod", Namespace: "default", Name: "web-0", Phase: "Running"}
+	assert.Equal(t, "Pod default/web-0 is now ready\n"+
+		"> Namespace: default\n"+
+		"> Status: Running\n"+
+		"> Phase: Running", renderMessage(ctx, tracker, pod))
+
+	failed := NotificationEvent{Type: EventFailed, Kind: "Pod", Namespace: "default", Name: "web-0",
+		Reason: "CrashLoopBackOff", Detail: "container app is waiting: CrashLoopBackOff"}
+	assert.Equal(t, "Pod default/web-0 has failed\n"+
+		"> Namespace: default\n"+
+		"> Status: Failed\n"+
+		"> Reason: CrashLoopBackOff\n"+
+		"> Message: container app is waiting: CrashLoopBackOff", renderMessage(ctx, tracker, failed))
+
+	imageChanged := NotificationEvent{Type: EventImageChanged, Kind: "Deployment", Namespace: "default", Name: "test-app",
+		Images: []string{"nginx:1.25"}, PreviousImages: []string{"nginx:1.24"}}
+	assert.Contains(t, renderMessage(ctx, tracker, imageChanged), "> Image: nginx:1.24 → nginx:1.25")
+
+	// 트래커별 템플릿과 헬퍼
+	tracker.Spec.Notify.Templates = &ddukbgv1alpha1.NotificationTemplates{
+		Ready:        `✅ {{ .Name }} 배포 완료 ({{ .Duration | duration }})`,
+		ImageChanged: `{{ .Name }}: {{ imageTag (index .PreviousImages 0) }} → {{ imageTag (index .Images 0) }}`,
+		Failed:       `{{ .Name }} failed: {{ .Detail | truncate 12 }}`,
+	}
+	deployment.Duration = 95*time.Second + 400*time.Millisecond
+	assert.Equal(t, "✅ test-app 배포 완료 (1m35s)", renderMessage(ctx, tracker, deployment))
+	assert.Equal(t, "test-app: 1.24 → 1.25", renderMessage(ctx, tracker, imageChanged))
+	assert.Equal(t, "web-0 failed: container ap", renderMessage(ctx, tracker, failed))
+
+	// 잘못된 템플릿은 기본 메시지로 대체
+	tracker.Spec.Notify.Templates.Ready = `{{ .Missing }}`
+	assert.Equal(t, readyMessage("Deployment", "default", "test-app", 3, 3), renderMessage(ctx, tracker, deployment))
+}
+
+func TestImageHelpers(t *testing.T) {
+	tests := []struct {
+		image  string
+		name   string
+		tag    string
+		digest string
+	}{
+		{image: "nginx", name: "nginx", tag: "latest"},
+		{image: "nginx:1.25", name: "nginx", tag: "1.25"},
+		{image: "registry:5000/team/app", name: "registry:5000/team/app", tag: "latest"},
+		{image: "registry:5000/team/app:v2", name: "registry:5000/team/app", tag: "v2"},
+		{image: "ghcr.io/org/app:1.2@sha256:abc", name: "ghcr.io/org/app", tag: "1.2", digest: "sha256:abc"},
+		{image: "ghcr.io/org/app@sha256:abc", name: "ghcr.io/org/app", tag: "", digest: "sha256:abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.name, imageName(tt.image))
+			assert.Equal(t, tt.tag, imageTag(tt.image))
+			assert.Equal(t, tt.digest, imageDigest(tt.image))
+		})
+	}
+}
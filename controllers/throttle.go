@@ -0,0 +1,260 @@
+// controllers/throttle.go
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	defaultDedupTTL        = 5 * time.Minute
+	defaultRateLimitPerMin = 20
+	defaultRateLimitBurst  = 10
+)
+
+// EventSuppressed is the type of the summary sent for rate limited notifications
+const EventSuppressed = "suppressed"
+
+// incidentChannels keep their own incident state and must see every transition,
+// so they are neither rate limited nor batched into digests
+var incidentChannels = map[string]bool{
+	ChannelPagerDuty: true,
+	ChannelOpsgenie:  true,
+}
+
+// transitionChannels are event sinks that receive every transition, including
+// not-ready events that would only add noise to chat and incident channels
+var transitionChannels = map[string]bool{
+	ChannelCloudEvents: true,
+	ChannelNATS:        true,
+	ChannelKafka:       true,
+}
+
+// threadedChannels post the updates of a rollout as replies in one thread, where
+// not-ready events of a revision show the progress of the rollout without flooding
+// the channel
+var threadedChannels = map[string]bool{
+	ChannelSlackBot: true,
+}
+
+// unbatched reports whether every notification is delivered to the channel as is,
+// without rate limiting, digests or quiet hours
+func unbatched(channel string) bool {
+	return incidentChannels[channel] || transitionChannels[channel]
+}
+
+// NotificationThrottle drops notifications that were already sent within a TTL and
+// rate limits every destination with a token bucket. Notifications suppressed by
+// the rate limit are summarised in one message once the destination has tokens again.
+type NotificationThrottle struct {
+	ttl   time.Duration
+	limit rate.Limit
+	burst int
+
+	mu         sync.Mutex
+	sent       map[string]time.Time
+	lastSweep  time.Time
+	limiters   map[string]*rate.Limiter
+	suppressed map[string]*suppressedEvents
+}
+
+// suppressedEvents counts the rate limited events of one destination by type and kind
+type suppressedEvents struct {
+	counts map[summaryGroup]int
+	order  []summaryGroup
+	// flush delivers the summary through the destination's notifier
+	flush func(summary NotificationEvent)
+}
+
+type summaryGroup struct {
+	eventType string
+	kind      string
+}
+
+// NewNotificationThrottle creates a throttle that deduplicates notifications for ttl
+// and allows perMinute notifications per destination with the given burst
+func NewNotificationThrottle(ttl time.Duration, perMinute float64, burst int) *NotificationThrottle {
+	if ttl <= 0 {
+		ttl = defaultDedupTTL
+	}
+	if perMinute <= 0 {
+		perMinute = defaultRateLimitPerMin
+	}
+	if burst <= 0 {
+		burst = defaultRateLimitBurst
+	}
+	return &NotificationThrottle{
+		ttl:        ttl,
+		limit:      rate.Limit(perMinute / 60),
+		burst:      burst,
+		sent:       make(map[string]time.Time),
+		limiters:   make(map[string]*rate.Limiter),
+		suppressed: make(map[string]*suppressedEvents),
+	}
+}
+
+// Duplicate reports whether the same notification was sent for the tracker within
+// the TTL, e.g. because a stale cache made Reconcile see the same transition again.
+// Otherwise it records the notification and returns false. Incident channels are
+// not deduplicated so that a page is never lost.
+func (t *NotificationThrottle) Duplicate(tracker types.NamespacedName, event NotificationEvent) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(t.lastSweep) > t.ttl {
+		for key, sentAt := range t.sent {
+			if now.Sub(sentAt) > t.ttl {
+				delete(t.sent, key)
+			}
+		}
+		t.lastSweep = now
+	}
+
+	key := dedupKey(tracker, event)
+	if sentAt, ok := t.sent[key]; ok && now.Sub(sentAt) <= t.ttl {
+		return true
+	}
+	t.sent[key] = now
+	return false
+}
+
+// Forget drops the record of a notification, so that it is not taken for a
+// duplicate when it is sent again
+func (t *NotificationThrottle) Forget(tracker types.NamespacedName, event NotificationEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.sent, dedupKey(tracker, event))
+}
+
+// dedupKey identifies a transition of one resource watched by a tracker. The time
+// the resource entered the previous state tells a flap (fail, recover, fail again
+// for the same reason) apart from the same transition seen twice.
+func dedupKey(tracker types.NamespacedName, event NotificationEvent) string {
+	return strings.Join([]string{
+		tracker.String(),
+		event.Type,
+		event.Namespace + "/" + event.Name,
+		event.PreviousTransition.UTC().Format(time.RFC3339Nano),
+		event.Revision,
+		event.Reason,
+		fmt.Sprintf("%d>%d", event.PreviousReplicas, event.TotalReplicas),
+		strings.Join(event.Images, ","),
+		event.Rollout.key(),
+	}, "|")
+}
+
+// Allow takes a token from the destination's bucket. When the bucket is empty the
+// event is counted instead, and flush is called with a summary of the suppressed
+// events as soon as the destination may be notified again.
+func (t *NotificationThrottle) Allow(destination string, event NotificationEvent, flush func(summary NotificationEvent)) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	limiter, ok := t.limiters[destination]
+	if !ok {
+		limiter = rate.NewLimiter(t.limit, t.burst)
+		t.limiters[destination] = limiter
+	}
+
+	pending, suppressing := t.suppressed[destination]
+	if !suppressing && limiter.Allow() {
+		return true
+	}
+
+	if !suppressing {
+		pending = &suppressedEvents{counts: make(map[summaryGroup]int)}
+		t.suppressed[destination] = pending
+		// 요약 메시지가 사용할 토큰을 미리 예약
+		time.AfterFunc(limiter.Reserve().Delay(), func() { t.flush(destination) })
+	}
+	group := summaryGroup{eventType: event.Type, kind: event.Kind}
+	if _, counted := pending.counts[group]; !counted {
+		pending.order = append(pending.order, group)
+	}
+	pending.counts[group]++
+	pending.flush = flush
+	return false
+}
+
+// flush sends the summary of the destination's suppressed events
+func (t *NotificationThrottle) flush(destination string) {
+	t.mu.Lock()
+	pending := t.suppressed[destination]
+	delete(t.suppressed, destination)
+	t.mu.Unlock()
+
+	if pending == nil {
+		return
+	}
+	pending.flush(pending.summary())
+}
+
+// summary builds one event listing the suppressed events, e.g. "12 more Pods became ready"
+func (s *suppressedEvents) summary() NotificationEvent {
+	event := NotificationEvent{Type: EventSuppressed, Severity: SeverityInfo, Timestamp: time.Now()}
+	lines := make([]string, 0, len(s.order))
+	for _, group := range s.order {
+		count := s.counts[group]
+		event.Count += count
+		kind := group.kind
+		if count > 1 {
+			kind += "s"
+		}
+		lines = append(lines, fmt.Sprintf("%d more %s %s", count, kind, summaryVerb(group.eventType, count)))
+	}
+	if len(s.order) == 1 {
+		event.Kind = s.order[0].kind
+	}
+	event.Message = strings.Join(lines, "\n")
+	return event
+}
+
+func summaryVerb(eventType string, count int) string {
+	switch eventType {
+	case EventReady:
+		return "became ready"
+	case EventNotReady:
+		return "became not ready"
+	case EventCompleted:
+		return "completed"
+	case EventFailed:
+		return "failed"
+	case EventImageChanged:
+		return "changed images"
+	case EventScaled:
+		if count > 1 {
+			return "were scaled"
+		}
+		return "was scaled"
+	case EventDeleted:
+		if count > 1 {
+			return "were deleted"
+		}
+		return "was deleted"
+	case EventRolloutStep:
+		return "moved to another step"
+	case EventRolloutPaused:
+		return "paused"
+	case EventAnalysis:
+		return "finished an analysis"
+	case EventRolloutAborted:
+		if count > 1 {
+			return "were aborted"
+		}
+		return "was aborted"
+	case EventRolloutPromoted:
+		if count > 1 {
+			return "were promoted"
+		}
+		return "was promoted"
+	default:
+		return "changed"
+	}
+}
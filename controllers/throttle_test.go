@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationThrottleDuplicate(t *testing.T) {
+	throttle := NewNotificationThrottle(time.Minute, 0, 0)
+	tracker := types.NamespacedName{Namespace: "default", Name: "test-tracker"}
+	event := NotificationEvent{Type: EventReady, Namespace: "default", Name: "test-app", Revision: "1"}
+
+	assert.False(t, throttle.Duplicate(tracker, event))
+	assert.True(t, throttle.Duplicate(tracker, event), "same transition within the TTL")
+
+	other := types.NamespacedName{Namespace: "default", Name: "other-tracker"}
+	assert.False(t, throttle.Duplicate(other, event), "dedup keys are per tracker")
+
+	rollout := event
+	rollout.Revision = "2"
+	assert.False(t, throttle.Duplicate(tracker, rollout), "a new rollout is a new transition")
+
+	failed := event
+	failed.Type = EventFailed
+	failed.Reason = "ProgressDeadlineExceeded"
+	assert.False(t, throttle.Duplicate(tracker, failed))
+
+	// 실패 → 복구 → 같은 사유로 다시 실패하면 직전 전환 시각이 달라 새 전환
+	refailed := failed
+	refailed.PreviousTransition = time.Now()
+	assert.False(t, throttle.Duplicate(tracker, refailed), "a flap is a new transition")
+	assert.True(t, throttle.Duplicate(tracker, refailed))
+
+	throttle.ttl = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	assert.False(t, throttle.Duplicate(tracker, event), "expired after the TTL")
+}
+
+func TestNotificationThrottleSummary(t *testing.T) {
+	// 100ms마다 토큰 1개, 버스트 2
+	throttle := NewNotificationThrottle(time.Minute, 600, 2)
+	summaries := make(chan NotificationEvent, 1)
+	flush := func(summary NotificationEvent) { summaries <- summary }
+
+	ready := NotificationEvent{Type: EventReady, Kind: "Pod"}
+	assert.True(t, throttle.Allow("default/test-tracker/slack", ready, flush))
+	assert.True(t, throttle.Allow("default/test-tracker/slack", ready, flush))
+	for i := 0; i < 12; i++ {
+		assert.False(t, throttle.Allow("default/test-tracker/slack", ready, flush))
+	}
+	assert.False(t, throttle.Allow("default/test-tracker/slack", NotificationEvent{Type: EventFailed, Kind: "Pod"}, flush))
+	assert.True(t, throttle.Allow("default/test-tracker/email", ready, flush), "buckets are per destination")
+
+	select {
+	case summary := <-summaries:
+		assert.Equal(t, EventSuppressed, summary.Type)
+		assert.Equal(t, 13, summary.Count)
+		assert.Equal(t, "12 more Pods became ready\n1 more Pod failed", summary.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("suppressed notifications were not summarised")
+	}
+}
+
+func TestSendNotificationsThrottle(t *testing.T) {
+	slack := newFakeNotifier()
+	pagerDuty := newFakeNotifier()
+	registry := NewNotifierRegistry()
+	registry.Register(ChannelSlack, slack)
+	registry.Register(ChannelPagerDuty, pagerDuty)
+
+	r := &ResourceTrackerReconciler{Notifiers: registry, Throttle: NewNotificationThrottle(time.Minute, 600, 1)}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Target: ddukbgv1alpha1.ResourceTarget{Kind: "Pod", Namespace: "apps"},
+		},
+	}
+
+	ctx := context.Background()
+	first := NotificationEvent{Type: EventFailed, Kind: "Pod", Namespace: "apps", Name: "web-0", Reason: "CrashLoopBackOff"}
+	require.NoError(t, r.sendNotifications(ctx, tracker, first))
+	require.NoError(t, r.sendNotifications(ctx, tracker, first), "duplicates are skipped")
+	assert.Len(t, slack.events, 1)
+	assert.Len(t, pagerDuty.events, 2, "incident channels are not deduplicated")
+
+	for i := 1; i <= 3; i++ {
+		event := first
+		event.Name = fmt.Sprintf("web-%d", i)
+		require.NoError(t, r.sendNotifications(ctx, tracker, event))
+	}
+	assert.Len(t, pagerDuty.events, 5, "incident channels are not rate limited")
+
+	<-slack.events
+	select {
+	case summary := <-slack.events:
+		assert.Equal(t, EventSuppressed, summary.Type)
+		assert.Equal(t, "apps", summary.Namespace)
+		assert.Equal(t, "3 more Pods failed", summary.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("suppressed notifications were not summarised")
+	}
+}
@@ -0,0 +1,124 @@
+// controllers/webhook.go
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+)
+
+// webhookRequest is a fully rendered generic webhook call
+type webhookRequest struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+	Body    []byte
+}
+
+// WebhookNotifier sends notification events to a generic HTTP endpoint
+type WebhookNotifier struct {
+	// Client reads the Secrets referenced by the webhook URL and headers
+	Client client.Reader
+}
+
+// Enabled implements Notifier
+func (n *WebhookNotifier) Enabled(tracker *ddukbgv1alpha1.ResourceTracker) bool {
+	return tracker.Spec.Notify.Webhook != nil
+}
+
+// Notify implements Notifier
+func (n *WebhookNotifier) Notify(ctx context.Context, tracker *ddukbgv1alpha1.ResourceTracker, event NotificationEvent) error {
+	req, err := webhookRequestFor(ctx, n.Client, tracker, event)
+	if err != nil {
+		return err
+	}
+	return postWebhook(ctx, req)
+}
+
+// postWebhook performs the webhook HTTP request
+func postWebhook(ctx context.Context, req webhookRequest) error {
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for name, value := range req.Headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	resp, err := notificationHTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newDeliveryError(ChannelWebhook, resp)
+	}
+	return nil
+}
+
+// webhookRequestFor resolves the tracker's webhook settings and renders the body for event
+func webhookRequestFor(ctx context.Context, c client.Reader, tracker *ddukbgv1alpha1.ResourceTracker,
+	event NotificationEvent) (webhookRequest, error) {
+	cfg := tracker.Spec.Notify.Webhook
+
+	req := webhookRequest{
+		URL:     cfg.URL,
+		Method:  cfg.Method,
+		Headers: make(map[string]string, len(cfg.Headers)),
+	}
+	if cfg.URLSecretRef != nil {
+		url, err := secretValue(ctx, c, tracker.Namespace, cfg.URLSecretRef)
+		if err != nil {
+			return webhookRequest{}, err
+		}
+		req.URL = url
+	}
+	if req.URL == "" {
+		return webhookRequest{}, fmt.Errorf("notify.webhook requires url or urlSecretRef")
+	}
+	if req.Method == "" {
+		req.Method = http.MethodPost
+	}
+
+	// 재전송된 알림을 수신 측에서 걸러낼 수 있도록 이벤트 ID 전달
+	if event.ID != "" {
+		req.Headers["Idempotency-Key"] = event.ID
+	}
+	for _, header := range cfg.Headers {
+		value := header.Value
+		if header.ValueFrom != nil {
+			v, err := secretValue(ctx, c, tracker.Namespace, header.ValueFrom)
+			if err != nil {
+				return webhookRequest{}, err
+			}
+			value = v
+		}
+		req.Headers[header.Name] = value
+	}
+
+	body, err := renderWebhookBody(cfg.Body, event)
+	if err != nil {
+		return webhookRequest{}, err
+	}
+	req.Body = body
+
+	return req, nil
+}
+
+// renderWebhookBody executes the body template over the event,
+// or encodes the event as JSON when no template is configured
+func renderWebhookBody(body string, event NotificationEvent) ([]byte, error) {
+	if body == "" {
+		return json.Marshal(event)
+	}
+	return renderEventTemplate("webhook body", body, event)
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ddukbgv1alpha1 "k8s-deploy-watcher/api/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWebhookNotification(t *testing.T) {
+	type received struct {
+		method string
+		header http.Header
+		body   string
+	}
+	requests := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{method: r.Method, header: r.Header, body: string(body)}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	_ = ddukbgv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "portal", Namespace: "default"},
+		Data: map[string][]byte{
+			"url":   []byte(server.URL),
+			"token": []byte("s3cr3t"),
+		},
+	}
+	tracker := &ddukbgv1alpha1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tracker", Namespace: "default"},
+		Spec: ddukbgv1alpha1.ResourceTrackerSpec{
+			Notify: ddukbgv1alpha1.NotifyConfig{
+				Webhook: &ddukbgv1alpha1.WebhookConfig{
+					URLSecretRef: &ddukbgv1alpha1.SecretKeyRef{Name: "portal", Key: "url"},
+					Method:       http.MethodPut,
+					Headers: []ddukbgv1alpha1.WebhookHeader{
+						{Name: "X-Source", Value: "k8s-deploy-watcher"},
+						{Name: "Authorization", ValueFrom: &ddukbgv1alpha1.SecretKeyRef{Name: "portal", Key: "token"}},
+					},
+					Body: `{"service": {{ json .Name }}, "ready": "{{ .ReadyReplicas }}/{{ .TotalReplicas }}", ` +
+						`"image": {{ json (join .Images ",") }}, "from": {{ json (join .PreviousImages ",") }}}`,
+				},
+			},
+		},
+	}
+
+	r := &ResourceTrackerReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+		Scheme: scheme,
+	}
+
+	event := NotificationEvent{
+		Type:           EventReady,
+		Kind:           "Deployment",
+		Namespace:      "default",
+		Name:           "test-app",
+		ReadyReplicas:  3,
+		TotalReplicas:  3,
+		Images:         []string{"nginx:1.20"},
+		PreviousImages: []string{"nginx:1.19"},
+	}
+	require.NoError(t, r.sendNotifications(context.Background(), tracker, event))
+
+	req := <-requests
+	assert.Equal(t, http.MethodPut, req.method)
+	assert.Equal(t, "s3cr3t", req.header.Get("Authorization"))
+	assert.Equal(t, "k8s-deploy-watcher", req.header.Get("X-Source"))
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.JSONEq(t, `{"service": "test-app", "ready": "3/3", "image": "nginx:1.20", "from": "nginx:1.19"}`, req.body)
+}
+
+func TestRenderWebhookBody(t *testing.T) {
+	event := NotificationEvent{Type: EventFailed, Kind: "Pod", Namespace: "default", Name: "web-0", Reason: "CrashLoopBackOff"}
+
+	// 템플릿이 없으면 이벤트 전체를 JSON으로 전송
+	body, err := renderWebhookBody("", event)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, "failed", decoded["type"])
+	assert.Equal(t, "CrashLoopBackOff", decoded["reason"])
+
+	body, err = renderWebhookBody(`{{ .Kind }} {{ .Namespace }}/{{ .Name }}: {{ .Reason }}`, event)
+	require.NoError(t, err)
+	assert.Equal(t, "Pod default/web-0: CrashLoopBackOff", string(body))
+
+	_, err = renderWebhookBody(`{{ .Missing }}`, event)
+	assert.Error(t, err)
+}
+
+func TestRecordImages(t *testing.T) {
+	tracker := &ddukbgv1alpha1.ResourceTracker{}
+
+	previous, changed := recordImages(tracker, "default/test-app", []string{"nginx:1.19", "envoy:1.28"})
+	assert.Nil(t, previous)
+	assert.True(t, changed)
+
+	previous, changed = recordImages(tracker, "default/test-app", []string{"nginx:1.19", "envoy:1.28"})
+	assert.Nil(t, previous)
+	assert.False(t, changed)
+
+	previous, changed = recordImages(tracker, "default/test-app", []string{"nginx:1.20", "envoy:1.28"})
+	assert.Equal(t, []string{"nginx:1.19", "envoy:1.28"}, previous)
+	assert.True(t, changed)
+
+	// 이미지 변경 후에도 이전 이미지를 유지
+	previous, changed = recordImages(tracker, "default/test-app", []string{"nginx:1.20", "envoy:1.28"})
+	assert.Equal(t, []string{"nginx:1.19", "envoy:1.28"}, previous)
+	assert.False(t, changed)
+}
@@ -5,12 +5,17 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -32,15 +37,42 @@ func init() {
 
 func main() {
 	var (
-		metricsAddr          string
-		enableLeaderElection bool
-		probeAddr            string
+		metricsAddr           string
+		enableLeaderElection  bool
+		probeAddr             string
+		notificationQueueSize int
+		notificationWorkers   int
+		dedupTTL              time.Duration
+		rateLimit             float64
+		rateLimitBurst        int
+		maintenanceConfigMap  string
+		outboxConfigMap       string
+		outboxShards          int
+		eventBusConfig        string
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager.")
+	flag.IntVar(&notificationQueueSize, "notification-queue-size", 100,
+		"Maximum number of pending notifications, including retries.")
+	flag.IntVar(&notificationWorkers, "notification-workers", 2,
+		"Number of workers delivering notifications.")
+	flag.DurationVar(&dedupTTL, "notification-dedup-ttl", 5*time.Minute,
+		"How long a sent notification suppresses identical ones for the same resource.")
+	flag.Float64Var(&rateLimit, "notification-rate-limit", 20,
+		"Notifications per minute allowed for each channel of a tracker.")
+	flag.IntVar(&rateLimitBurst, "notification-rate-limit-burst", 10,
+		"Notifications each channel of a tracker may send at once before the rate limit applies.")
+	flag.StringVar(&maintenanceConfigMap, "maintenance-configmap", "",
+		"Namespace/name of a ConfigMap with cluster-wide maintenance windows honoured by every tracker.")
+	flag.StringVar(&outboxConfigMap, "notification-outbox", "",
+		"Namespace/name of a ConfigMap persisting pending notifications across restarts. Disabled when empty.")
+	flag.IntVar(&outboxShards, "notification-outbox-shards", 4,
+		"Number of ConfigMaps the notification outbox is spread over, named <name>, <name>-1, ...")
+	flag.StringVar(&eventBusConfig, "event-bus-config", "",
+		"Path of a YAML file configuring the NATS and Kafka buses every transition is published to.")
 
 	opts := zap.Options{
 		Development: true,
@@ -57,17 +89,72 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "d5375419.ddukbg.k8s", // Group 명에 맞게 수정
+		// Secret 값은 캐시에 두지 않고 알림을 보낼 때마다 API 서버에서 읽음
+		Client: client.Options{Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}}},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// 알림 전송 큐 설정
+	notifications := controllers.NewNotificationQueue(notificationQueueSize, notificationWorkers)
+	if err := mgr.Add(notifications); err != nil {
+		setupLog.Error(err, "unable to set up notification queue")
+		os.Exit(1)
+	}
+
+	// 클러스터 공통 유지보수 창 ConfigMap
+	maintenance := namespacedNameFlag("maintenance-configmap", maintenanceConfigMap)
+
+	// 재시작 후에도 알림을 보내기 위한 outbox 설정
+	var outbox *controllers.NotificationOutbox
+	if outboxConfigMap != "" {
+		outbox = controllers.NewNotificationOutbox(mgr.GetClient(), mgr.GetAPIReader(),
+			namespacedNameFlag("notification-outbox", outboxConfigMap), dedupTTL, outboxShards)
+		if err := mgr.Add(outbox); err != nil {
+			setupLog.Error(err, "unable to set up notification outbox")
+			os.Exit(1)
+		}
+	}
+
+	// 모든 상태 전이를 발행할 메시지 버스 설정
+	notifiers := controllers.NewDefaultNotifierRegistry(mgr.GetClient())
+	if eventBusConfig != "" {
+		cfg, err := controllers.LoadEventBusConfig(eventBusConfig)
+		if err != nil {
+			setupLog.Error(err, "unable to load event bus config")
+			os.Exit(1)
+		}
+		if cfg.NATS != nil {
+			publisher, err := controllers.NewNATSNotifier(cfg.NATS)
+			if err != nil {
+				setupLog.Error(err, "unable to set up NATS publisher")
+				os.Exit(1)
+			}
+			defer publisher.Close()
+			if !cfg.NATS.JetStream {
+				setupLog.Info("NATS events are published without JetStream and may be lost (at most once)")
+			}
+			notifiers.Register(controllers.ChannelNATS, publisher)
+		}
+		if cfg.Kafka != nil {
+			notifiers.Register(controllers.ChannelKafka, controllers.NewKafkaNotifier(cfg.Kafka))
+		}
+	}
+
 	// ResourceTrackerReconciler 설정
 	if err = (&controllers.ResourceTrackerReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("resource-tracker"),
+		Client:               mgr.GetClient(),
+		APIReader:            mgr.GetAPIReader(),
+		Scheme:               mgr.GetScheme(),
+		Recorder:             mgr.GetEventRecorderFor("resource-tracker"),
+		Notifiers:            notifiers,
+		Notifications:        notifications,
+		Throttle:             controllers.NewNotificationThrottle(dedupTTL, rateLimit, rateLimitBurst),
+		Digests:              controllers.NewDigestBuffer(),
+		MaintenanceConfigMap: maintenance,
+		Outbox:               outbox,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ResourceTracker")
 		os.Exit(1)
@@ -89,3 +176,16 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// namespacedNameFlag parses a namespace/name flag value, exiting on invalid values
+func namespacedNameFlag(flagName, value string) types.NamespacedName {
+	if value == "" {
+		return types.NamespacedName{}
+	}
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" {
+		setupLog.Error(nil, "flag must be namespace/name", "flag", flagName, "value", value)
+		os.Exit(1)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}